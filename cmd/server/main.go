@@ -4,15 +4,16 @@ import (
 	"flag"
 	"fmt"
 	"log"
-	"net/http"
+	"log/slog"
 	"oapi-codegen-layout/internal/config"
-	"oapi-codegen-layout/internal/database"
-	"oapi-codegen-layout/internal/router"
+	"oapi-codegen-layout/internal/logging"
+	"os"
 )
 
 func main() {
 	// Define command-line flags
 	configPath := flag.String("config", "", "Path to configuration file (default: auto-search in . and ./configs)")
+	flag.Usage = usage
 	flag.Parse()
 
 	// Load configuration
@@ -21,21 +22,57 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
-	log.Printf("Starting application in %s mode", cfg.Server.Mode)
-
-	// Initialize database connection
-	db, err := database.InitDB(&cfg.Database)
+	// Route all logs, including those of the log package, through slog
+	logger, err := logging.New(cfg.Log, os.Stderr)
 	if err != nil {
-		log.Fatalf("Failed to initialize database: %v", err)
+		log.Fatalf("Failed to configure logging: %v", err)
 	}
+	slog.SetDefault(logger)
 
-	// Setup router with all routes and middleware
-	r := router.Setup(&cfg.Server, db)
-
-	// Start server
-	addr := fmt.Sprintf(":%s", cfg.Server.Port)
-	log.Printf("Starting server on %s", addr)
-	if err := http.ListenAndServe(addr, r); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	// Dispatch to the requested command; serving the API is the default
+	switch command := flag.Arg(0); command {
+	case "", "serve":
+		runServer(cfg)
+	case "migrate":
+		runMigrate(cfg, flag.Args()[1:])
+	case "users":
+		runUsers(cfg, flag.Args()[1:])
+	case "spec":
+		runSpec(cfg, flag.Args()[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", command)
+		usage()
+		os.Exit(2)
 	}
 }
+
+// fatal logs an error that prevents a command from completing and exits.
+// It logs at error level, so failures are reported whatever the log level.
+func fatal(msg string, err error) {
+	slog.Error(msg, slog.Any("error", err))
+	os.Exit(1)
+}
+
+// usage prints the available commands and flags
+func usage() {
+	fmt.Fprintf(os.Stderr, `Usage: server [flags] [command]
+
+Commands:
+  serve                  Start the API server (default)
+  migrate up             Apply all pending migrations
+  migrate down [N]       Roll back the last N migrations (default 1)
+  migrate status         Show applied and pending migrations
+  migrate create NAME    Create a new empty migration for every driver
+  users set-role [-tenant T] ID ROLE
+                         Grant a user the viewer, editor or admin role
+  spec bundle [-o FILE]  Merge the split specs into api/openapi.bundle.yaml
+  spec diff -base FILE|REF
+                         Report changes since a bundled spec; fails on
+                         breaking changes without a version bump
+  spec lint [-format text|json]
+                         Check the split specs against the project conventions
+
+Flags:
+`)
+	flag.PrintDefaults()
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"oapi-codegen-layout/internal/config"
+	"oapi-codegen-layout/internal/database"
+	"oapi-codegen-layout/internal/database/migrate"
+)
+
+// runMigrate implements the "migrate" command
+func runMigrate(cfg *config.Config, args []string) {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	dir := fs.String("dir", "internal/database/migrate/migrations", "Migrations source directory (used by create)")
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	// create only writes files and does not need a database connection
+	if fs.Arg(0) == "create" {
+		if fs.NArg() != 2 {
+			usage()
+			os.Exit(2)
+		}
+		paths, err := migrate.Create(*dir, fs.Arg(1))
+		if err != nil {
+			fatal("Failed to create migration", err)
+		}
+		for _, path := range paths {
+			fmt.Println(path)
+		}
+		return
+	}
+
+	migrator := newMigrator(cfg)
+	ctx := context.Background()
+
+	switch fs.Arg(0) {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			fatal("Failed to apply migrations", err)
+		}
+		slog.Info("Applied migrations", slog.Int("count", applied))
+	case "down":
+		steps := 1
+		if fs.NArg() > 1 {
+			n, err := strconv.Atoi(fs.Arg(1))
+			if err != nil || n < 1 {
+				fatal("Invalid number of steps", fmt.Errorf("%q is not a positive number", fs.Arg(1)))
+			}
+			steps = n
+		}
+		rolledBack, err := migrator.Down(ctx, steps)
+		if err != nil {
+			fatal("Failed to roll back migrations", err)
+		}
+		slog.Info("Rolled back migrations", slog.Int("count", rolledBack))
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			fatal("Failed to read migration status", err)
+		}
+		printMigrationStatus(statuses)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown migrate command %q\n\n", fs.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+}
+
+// newMigrator connects to the configured database and creates a migrator for it
+func newMigrator(cfg *config.Config) *migrate.Migrator {
+	db, err := database.InitDB(&cfg.Database)
+	if err != nil {
+		fatal("Failed to initialize database", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		fatal("Failed to access database pool", err)
+	}
+
+	migrator, err := migrate.New(sqlDB, cfg.Database.Driver)
+	if err != nil {
+		fatal("Failed to load migrations", err)
+	}
+	return migrator
+}
+
+// printMigrationStatus writes the migration status as a table to stdout
+func printMigrationStatus(statuses []migrate.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+	w.Flush()
+}
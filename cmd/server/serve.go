@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+	"oapi-codegen-layout/internal/auth"
+	"oapi-codegen-layout/internal/config"
+	"oapi-codegen-layout/internal/database"
+	"oapi-codegen-layout/internal/database/migrate"
+	"oapi-codegen-layout/internal/healthcheck"
+	"oapi-codegen-layout/internal/metrics"
+	"oapi-codegen-layout/internal/router"
+	"oapi-codegen-layout/internal/tracing"
+)
+
+// runServer starts the HTTP API server and drains it on SIGINT or SIGTERM
+func runServer(cfg *config.Config) {
+	slog.Info("Starting application", slog.String("mode", cfg.Server.Mode))
+	if cfg.Server.CursorSecret == "" {
+		slog.Warn("server.cursor_secret is not set; pagination cursors will not survive restarts")
+	}
+
+	// Install the tracer provider before anything creates spans
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		fatal("Failed to initialize tracing", err)
+	}
+
+	// Initialize database connection
+	db, err := database.InitDB(&cfg.Database)
+	if err != nil {
+		fatal("Failed to initialize database", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		fatal("Failed to access database pool", err)
+	}
+
+	deps := router.NewDependencies(db)
+	deps.Logger = slog.Default()
+	deps.Draining = new(atomic.Bool)
+	deps.Health = setupHealthChecks(cfg, sqlDB)
+	var metricsServer *http.Server
+	if cfg.Metrics.Enabled {
+		deps.Metrics, deps.MetricsPath, metricsServer = setupMetrics(cfg, db)
+	}
+	if cfg.Auth.Enabled() {
+		deps.Verifier, err = auth.NewVerifier(cfg.Auth)
+		if err != nil {
+			fatal("Failed to initialize authentication", err)
+		}
+		if cfg.Auth.JWTSecret != "" {
+			deps.Sessions, err = auth.NewSessions(cfg.Auth, deps.Users, deps.RefreshTokens)
+			if err != nil {
+				fatal("Failed to initialize password login", err)
+			}
+		}
+	} else if cfg.Auth.APIKeys {
+		slog.Info("auth.jwt_secret and auth.jwks_file are not set; requests authenticate with API keys only")
+	} else {
+		slog.Warn("auth.jwt_secret, auth.jwks_file and auth.api_keys are not set; the API is served without authentication")
+	}
+	deps.APIKeyAuth = cfg.Auth.APIKeys
+
+	// Setup router with all routes and middleware
+	r, err := router.Setup(&cfg.Server, deps)
+	if err != nil {
+		fatal("Failed to set up router", err)
+	}
+
+	servers := []*http.Server{newHTTPServer(cfg.Server, cfg.Server.Port, r)}
+	if metricsServer != nil {
+		servers = append(servers, metricsServer)
+	}
+
+	// Start the servers; the first one to fail stops the process
+	failed := make(chan error, len(servers))
+	for _, srv := range servers {
+		go func() {
+			slog.Info("Starting server", slog.String("addr", srv.Addr))
+			if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				failed <- fmt.Errorf("server on %s: %w", srv.Addr, err)
+			}
+		}()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	select {
+	case err := <-failed:
+		fatal("Failed to start server", err)
+	case <-ctx.Done():
+	}
+	// A second signal interrupts the drain
+	stop()
+
+	// Fail the health check first so load balancers stop sending requests
+	slog.Info("Shutting down", slog.Duration("delay", cfg.Server.ShutdownDelay))
+	deps.Draining.Store(true)
+	time.Sleep(cfg.Server.ShutdownDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	for _, srv := range servers {
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			slog.Warn("In-flight requests did not finish in time; closing connections",
+				slog.String("addr", srv.Addr), slog.Any("error", err))
+			srv.Close()
+		}
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Warn("Failed to flush trace spans", slog.Any("error", err))
+	}
+	if err := sqlDB.Close(); err != nil {
+		slog.Warn("Failed to close database pool", slog.Any("error", err))
+	}
+	slog.Info("Server stopped")
+}
+
+// newHTTPServer creates an http.Server on port with the limits of cfg
+func newHTTPServer(cfg config.ServerConfig, port string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%s", port),
+		Handler:           handler,
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+		ErrorLog:          slog.NewLogLogger(slog.Default().Handler(), slog.LevelWarn),
+	}
+}
+
+// setupHealthChecks registers the dependency checks of the readiness probe
+func setupHealthChecks(cfg *config.Config, sqlDB *sql.DB) *healthcheck.Registry {
+	checks := healthcheck.NewRegistry(cfg.Health.CheckTimeout)
+	checks.Register("database", true, healthcheck.Ping(sqlDB))
+
+	// AutoMigrate does not record versions, so only check versioned migrations
+	if !cfg.Database.AutoMigrate {
+		migrator, err := migrate.New(sqlDB, cfg.Database.Driver)
+		if err != nil {
+			fatal("Failed to load migrations", err)
+		}
+		checks.Register("migrations", true, healthcheck.Migrations(migrator))
+	}
+
+	// The disk check would only ever fail where free space cannot be read
+	switch {
+	case cfg.Health.DiskMinFreeMB == 0:
+	case !healthcheck.DiskSpaceSupported():
+		slog.Info("Disk space health check is not supported on this platform")
+	default:
+		checks.Register("disk", false, healthcheck.DiskSpace(cfg.Health.DiskPath, cfg.Health.DiskMinFreeMB<<20))
+	}
+	return checks
+}
+
+// setupMetrics creates the metrics registry with the runtime and connection
+// pool collectors. It returns the path to serve the metrics under on the API
+// port, or the admin server to serve them on when metrics.port is set.
+func setupMetrics(cfg *config.Config, db *gorm.DB) (*prometheus.Registry, string, *http.Server) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		fatal("Failed to access database pool", err)
+	}
+	registry := metrics.NewRegistry(sqlDB)
+
+	if cfg.Metrics.Port == "" {
+		return registry, cfg.Metrics.Path, nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET "+cfg.Metrics.Path, metrics.Handler(registry))
+	return registry, "", newHTTPServer(cfg.Server, cfg.Metrics.Port, mux)
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"oapi-codegen-layout/internal/apispec"
+	"oapi-codegen-layout/internal/config"
+	"oapi-codegen-layout/internal/router"
+)
+
+// bundleHeader starts the bundled spec so nobody edits it by hand
+const bundleHeader = "# Code generated by \"server spec bundle\"; DO NOT EDIT.\n" +
+	"# Edit the split specs under api/specs and api/schemas instead.\n"
+
+// runSpec implements the "spec" command
+func runSpec(_ *config.Config, args []string) {
+	if len(args) == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("spec "+args[0], flag.ExitOnError)
+	dir := fs.String("dir", "api/specs", "Directory holding the split spec of each domain")
+
+	switch args[0] {
+	case "bundle":
+		out := fs.String("o", "api/openapi.bundle.yaml", "Output file of the bundled spec")
+		fs.Parse(args[1:])
+
+		bundle, err := bundleSpec(*dir)
+		if err != nil {
+			fatal("Failed to bundle OpenAPI specs", err)
+		}
+		data, err := apispec.EncodeYAML(bundle)
+		if err != nil {
+			fatal("Failed to encode bundled spec", err)
+		}
+		if err := os.WriteFile(*out, append([]byte(bundleHeader), data...), 0o644); err != nil {
+			fatal("Failed to write bundled spec", err)
+		}
+		slog.Info("Bundled OpenAPI specs", slog.Int("paths", bundle.Paths.Len()), slog.String("file", *out))
+	case "diff":
+		base := fs.String("base", "", "Bundled spec file, or git ref whose bundled spec to compare against")
+		bundlePath := fs.String("bundle", "api/openapi.bundle.yaml", "Path of the bundled spec within the git ref")
+		fs.Parse(args[1:])
+		if *base == "" {
+			usage()
+			os.Exit(2)
+		}
+		diffSpec(*dir, *base, *bundlePath)
+	case "lint":
+		format := fs.String("format", "text", "Output format of the findings: text or json")
+		fs.Parse(args[1:])
+		if *format != "text" && *format != "json" {
+			fatal("Invalid output format", fmt.Errorf("%q is not text or json", *format))
+		}
+		lintSpecs(*dir, *format)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown spec command %q\n\n", args[0])
+		usage()
+		os.Exit(2)
+	}
+}
+
+// diffSpec prints the changes between the base spec and the split specs in
+// dir, and exits with status 1 on breaking changes unless info.version was
+// bumped
+func diffSpec(dir, base, bundlePath string) {
+	revision, err := bundleSpec(dir)
+	if err != nil {
+		fatal("Failed to bundle OpenAPI specs", err)
+	}
+	baseSpec, err := loadBaseSpec(base, bundlePath)
+	if err != nil {
+		fatal("Failed to load base spec", err)
+	}
+
+	breaking := 0
+	for _, change := range apispec.Diff(baseSpec, revision) {
+		fmt.Println(change)
+		if change.Breaking {
+			breaking++
+		}
+	}
+	if breaking == 0 {
+		return
+	}
+
+	bumped, err := apispec.VersionBumped(baseSpec, revision)
+	if err != nil {
+		fatal("Failed to compare versions", err)
+	}
+	if !bumped {
+		fatal("Breaking changes without a version bump", fmt.Errorf(
+			"%d breaking change(s); raise info.version above %s", breaking, baseSpec.Info.Version))
+	}
+	slog.Info("Breaking changes with a version bump", slog.Int("breaking", breaking),
+		slog.String("from", baseSpec.Info.Version), slog.String("to", revision.Info.Version))
+}
+
+// lintSpecs checks every split spec in dir against the project conventions,
+// prints the findings and exits with status 1 if there are any
+func lintSpecs(dir, format string) {
+	files, err := filepath.Glob(filepath.Join(dir, "*", "api.yaml"))
+	if err != nil {
+		fatal("Failed to list specs", err)
+	}
+	if len(files) == 0 {
+		fatal("Failed to list specs", fmt.Errorf("no specs found in %s", dir))
+	}
+
+	domains := make([]apispec.Domain, 0, len(files))
+	for _, file := range files {
+		spec, err := apispec.LoadFile(file)
+		if err != nil {
+			fatal("Failed to load spec", err)
+		}
+		domains = append(domains, apispec.Domain{Name: filepath.Base(filepath.Dir(file)), Spec: spec})
+	}
+
+	findings := apispec.Lint(domains)
+	if format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		// An empty array rather than null keeps consumers simple
+		if err := encoder.Encode(append([]apispec.Finding{}, findings...)); err != nil {
+			fatal("Failed to encode findings", err)
+		}
+	} else {
+		for _, finding := range findings {
+			fmt.Println(finding)
+		}
+	}
+	if len(findings) > 0 {
+		fatal("Specs violate the project conventions", fmt.Errorf(
+			"%d finding(s) in %d spec(s)", len(findings), len(domains)))
+	}
+}
+
+// loadBaseSpec loads the spec to compare against from a file, or from the
+// bundled spec committed at a git ref
+func loadBaseSpec(base, bundlePath string) (*openapi3.T, error) {
+	if _, err := os.Stat(base); err == nil {
+		return apispec.LoadFile(base)
+	}
+
+	// "./" makes git resolve the path relative to the working directory
+	object := base + ":./" + filepath.ToSlash(filepath.Clean(bundlePath))
+	data, err := exec.Command("git", "show", object).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("%s is neither a file nor a git ref containing %s: %s",
+				base, bundlePath, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+	spec, err := openapi3.NewLoader().LoadFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", object, err)
+	}
+	return spec, nil
+}
+
+// bundleSpec loads the split spec of every domain served by the API from
+// dir, resolves their external references and merges them into a single
+// validated document
+func bundleSpec(dir string) (*openapi3.T, error) {
+	registry := apispec.NewRegistry()
+	for _, name := range router.SpecRegistry().Names() {
+		filename := filepath.Join(dir, name, "api.yaml")
+		registry.Register(name, func() (*openapi3.T, error) {
+			return apispec.LoadFile(filename)
+		})
+	}
+
+	domains, err := registry.Load()
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := apispec.Merge(router.APIInfo(), domains)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge %s: %w", dir, err)
+	}
+	return bundle, nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/google/uuid"
+	"oapi-codegen-layout/internal/authz"
+	"oapi-codegen-layout/internal/config"
+	"oapi-codegen-layout/internal/database"
+	"oapi-codegen-layout/internal/repository"
+	"oapi-codegen-layout/internal/tenant"
+)
+
+// runUsers implements the "users" command. It lets operators grant the first
+// admin role, which the API itself only allows admins to do.
+func runUsers(cfg *config.Config, args []string) {
+	fs := flag.NewFlagSet("users", flag.ExitOnError)
+	tenantID := fs.String("tenant", tenant.Default, "Tenant of the user")
+	fs.Parse(args)
+
+	if fs.NArg() != 3 || fs.Arg(0) != "set-role" {
+		usage()
+		os.Exit(2)
+	}
+
+	id, err := uuid.Parse(fs.Arg(1))
+	if err != nil {
+		fatal("Invalid user ID", fmt.Errorf("%q: %w", fs.Arg(1), err))
+	}
+	role, ok := authz.ParseRole(fs.Arg(2))
+	if !ok {
+		fatal("Invalid role", fmt.Errorf("%q is not viewer, editor or admin", fs.Arg(2)))
+	}
+	if !tenant.ValidID(*tenantID) {
+		fatal("Invalid tenant", fmt.Errorf("%q is not a valid tenant ID", *tenantID))
+	}
+
+	db, err := database.InitDB(&cfg.Database)
+	if err != nil {
+		fatal("Failed to initialize database", err)
+	}
+
+	ctx := tenant.WithID(context.Background(), *tenantID)
+	users := repository.NewGormUserRepository(db)
+	user, err := users.Get(ctx, id)
+	if err != nil {
+		fatal("Failed to load user", fmt.Errorf("user %s: %w", id, err))
+	}
+	user.Role = string(role)
+	if err := users.Update(ctx, user); err != nil {
+		fatal("Failed to update user", fmt.Errorf("user %s: %w", id, err))
+	}
+	slog.Info("Role granted", slog.String("user", user.Email), slog.String("role", string(role)))
+}
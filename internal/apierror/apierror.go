@@ -0,0 +1,94 @@
+// Package apierror writes API errors in the shape of the Error schema, or as
+// RFC 7807 problem details when the client asks for application/problem+json.
+package apierror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+// ContentTypeProblem is the media type of RFC 7807 problem details
+const ContentTypeProblem = "application/problem+json"
+
+// parameterPattern extracts the parameter name from the errors reported by
+// the generated ServerInterfaceWrapper when binding fails
+var parameterPattern = regexp.MustCompile(`^(?:Invalid format for parameter|Query argument|Header parameter|Expected one value for) ([^\s:,]+)`)
+
+// Respond writes apiErr with the given status, as problem details when the
+// Accept header prefers application/problem+json
+func Respond(c *gin.Context, status int, apiErr apimodels.Error) {
+	if c.NegotiateFormat(binding.MIMEJSON, ContentTypeProblem) != ContentTypeProblem {
+		c.JSON(status, apiErr)
+		return
+	}
+
+	instance := c.Request.URL.RequestURI()
+	detail := apiErr.Message
+	c.Header("Content-Type", ContentTypeProblem)
+	c.JSON(status, apimodels.Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   &detail,
+		Instance: &instance,
+		Code:     apiErr.Code,
+		Message:  apiErr.Message,
+		Field:    apiErr.Field,
+		Details:  apiErr.Details,
+	})
+}
+
+// Abort writes apiErr like Respond and stops the remaining handlers
+func Abort(c *gin.Context, status int, apiErr apimodels.Error) {
+	Respond(c, status, apiErr)
+	c.Abort()
+}
+
+// RespondTimeout writes the 504 timeout error for a request whose deadline passed
+func RespondTimeout(c *gin.Context) {
+	Respond(c, http.StatusGatewayTimeout, apimodels.Error{
+		Code:    "timeout",
+		Message: "The request did not complete before its deadline",
+	})
+}
+
+// DeadlineExceeded reports whether err was caused by the request deadline
+// passing. Drivers do not always wrap the context error, so the request
+// context is checked as well.
+func DeadlineExceeded(c *gin.Context, err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(c.Request.Context().Err(), context.DeadlineExceeded)
+}
+
+// HandleParameterError is the GinServerOptions.ErrorHandler shared by all
+// domains. It reports parameters the generated wrapper failed to bind as
+// invalid_parameter errors naming the parameter.
+func HandleParameterError(c *gin.Context, err error, status int) {
+	match := parameterPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		Abort(c, status, apimodels.Error{
+			Code:    "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	name := match[1]
+	reason := err.Error()
+	if cause := errors.Unwrap(err); cause != nil {
+		reason = cause.Error()
+	}
+	Abort(c, status, apimodels.Error{
+		Code:    "invalid_parameter",
+		Message: fmt.Sprintf("Invalid value for parameter %s", name),
+		Field:   &name,
+		Details: &[]apimodels.ErrorDetail{{Field: name, Message: reason}},
+	})
+}
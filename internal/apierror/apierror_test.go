@@ -0,0 +1,78 @@
+package apierror_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"oapi-codegen-layout/internal/apierror"
+	"oapi-codegen-layout/internal/testutil"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+func TestInvalidParameter(t *testing.T) {
+	s := testutil.NewServer(t)
+
+	for _, path := range []string{"/users/not-a-uuid", "/products/not-a-uuid", "/products?limit=abc"} {
+		t.Run(path, func(t *testing.T) {
+			apiErr := testutil.ExpectError(t, s.Do(t, http.MethodGet, path, nil), http.StatusBadRequest, "invalid_parameter")
+			if apiErr.Field == nil || *apiErr.Field == "" {
+				t.Fatalf("expected the parameter name in field, got %+v", apiErr)
+			}
+		})
+	}
+}
+
+func TestProblemDetails(t *testing.T) {
+	s := testutil.NewServer(t)
+
+	req := s.NewRequest(t, http.MethodGet, "/products/not-a-uuid", nil)
+	req.Header.Set("Accept", apierror.ContentTypeProblem)
+	resp := s.Serve(req)
+
+	if resp.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d: %s", resp.Code, resp.Body)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != apierror.ContentTypeProblem {
+		t.Fatalf("expected Content-Type %q, got %q", apierror.ContentTypeProblem, ct)
+	}
+
+	var problem apimodels.Problem
+	if err := json.Unmarshal(resp.Body, &problem); err != nil {
+		t.Fatalf("failed to decode problem: %v", err)
+	}
+	if problem.Status != http.StatusBadRequest || problem.Title != "Bad Request" || problem.Code != "invalid_parameter" {
+		t.Fatalf("unexpected problem %+v", problem)
+	}
+	if problem.Field == nil || *problem.Field != "productId" {
+		t.Fatalf("expected field productId, got %v", problem.Field)
+	}
+	if problem.Instance == nil || *problem.Instance != testutil.BasePath+"/products/not-a-uuid" {
+		t.Fatalf("unexpected instance %v", problem.Instance)
+	}
+}
+
+func TestProblemDetailsForHandlerErrors(t *testing.T) {
+	s := testutil.NewServer(t)
+
+	req := s.NewRequest(t, http.MethodGet, "/users/00000000-0000-0000-0000-000000000001", nil)
+	req.Header.Set("Accept", apierror.ContentTypeProblem+", application/json;q=0.5")
+	resp := s.Serve(req)
+
+	if resp.Code != http.StatusNotFound || resp.Header.Get("Content-Type") != apierror.ContentTypeProblem {
+		t.Fatalf("expected a 404 problem, got %d %q: %s", resp.Code, resp.Header.Get("Content-Type"), resp.Body)
+	}
+}
+
+func TestJSONByDefault(t *testing.T) {
+	s := testutil.NewServer(t)
+
+	req := s.NewRequest(t, http.MethodGet, "/users/not-a-uuid", nil)
+	req.Header.Set("Accept", "*/*")
+	resp := s.Serve(req)
+
+	testutil.ExpectError(t, resp, http.StatusBadRequest, "invalid_parameter")
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json; charset=utf-8" {
+		t.Fatalf("expected JSON, got %q", ct)
+	}
+}
@@ -0,0 +1,469 @@
+package apispec
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Change is a difference between two revisions of a spec that clients can notice
+type Change struct {
+	// Breaking is set when clients built against the base revision may fail
+	// with the new one
+	Breaking bool
+	// Operation is the method and path the change concerns, such as "GET /users"
+	Operation string
+	Message   string
+}
+
+// String formats the change as a single line
+func (c Change) String() string {
+	level := "non-breaking"
+	if c.Breaking {
+		level = "breaking"
+	}
+	return fmt.Sprintf("%s: %s: %s", level, c.Operation, c.Message)
+}
+
+// Diff compares the operations of revision with those of base. Request
+// schemas break clients when they accept less than before, response schemas
+// when they may return more than before. Operations are matched by method
+// and path, ignoring the names of path parameters.
+func Diff(base, revision *openapi3.T) []Change {
+	d := &differ{seen: make(map[[2]*openapi3.Schema]bool)}
+	baseOps, revisionOps := operationsByRoute(base), operationsByRoute(revision)
+
+	for _, route := range slices.Sorted(maps.Keys(baseOps)) {
+		b := baseOps[route]
+		r, ok := revisionOps[route]
+		if !ok {
+			d.op = b.Method + " " + b.Path
+			d.add(true, "operation removed")
+			continue
+		}
+		d.op = r.Method + " " + r.Path
+		d.operation(b, r)
+	}
+	for _, route := range slices.Sorted(maps.Keys(revisionOps)) {
+		if _, ok := baseOps[route]; !ok {
+			r := revisionOps[route]
+			d.op = r.Method + " " + r.Path
+			d.add(false, "operation added")
+		}
+	}
+	return d.changes
+}
+
+// VersionBumped reports whether the info.version of revision is higher than
+// the one of base. Versions are compared as dot-separated numbers such as
+// 1.2.0; a leading "v" is ignored.
+func VersionBumped(base, revision *openapi3.T) (bool, error) {
+	if base.Info == nil || revision.Info == nil {
+		return false, fmt.Errorf("info.version is missing")
+	}
+	b, err := parseVersion(base.Info.Version)
+	if err != nil {
+		return false, err
+	}
+	r, err := parseVersion(revision.Info.Version)
+	if err != nil {
+		return false, err
+	}
+	return slices.Compare(r, b) > 0, nil
+}
+
+// parseVersion splits a version into its numbers
+func parseVersion(version string) ([]int, error) {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("info.version %q is not a dot-separated number", version)
+		}
+		numbers[i] = n
+	}
+	return numbers, nil
+}
+
+// operationsByRoute returns the operations of spec keyed by method and path
+// with anonymous path parameters
+func operationsByRoute(spec *openapi3.T) map[string]*Operation {
+	ops := make(map[string]*Operation)
+	if spec.Paths == nil {
+		return ops
+	}
+	for path, item := range spec.Paths.Map() {
+		route := pathParamPattern.ReplaceAllString(path, "{}")
+		for method, operation := range item.Operations() {
+			ops[method+" "+route] = &Operation{
+				Spec:      spec,
+				Path:      path,
+				PathItem:  item,
+				Method:    method,
+				Operation: operation,
+			}
+		}
+	}
+	return ops
+}
+
+// differ collects the changes between two revisions
+type differ struct {
+	// op is the operation the changes being recorded concern
+	op      string
+	changes []Change
+	// seen holds the schema pairs already compared, so recursive schemas end
+	seen map[[2]*openapi3.Schema]bool
+}
+
+// add records a change of the current operation
+func (d *differ) add(breaking bool, format string, args ...any) {
+	d.changes = append(d.changes, Change{
+		Breaking:  breaking,
+		Operation: d.op,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+// operation compares two revisions of an operation
+func (d *differ) operation(b, r *Operation) {
+	if !strings.EqualFold(b.ID(), r.ID()) {
+		d.add(true, "operationId changed from %s to %s", b.ID(), r.ID())
+	}
+	d.security(b.Security(), r.Security())
+	d.parameters(parameters(b), parameters(r))
+	d.requestBody(b.Operation.RequestBody, r.Operation.RequestBody)
+	d.responses(b.Operation.Responses, r.Operation.Responses)
+}
+
+// security compares the security requirements of an operation. A client
+// keeps working as long as every requirement it could satisfy is still
+// accepted without additional schemes or scopes.
+func (d *differ) security(b, r openapi3.SecurityRequirements) {
+	if sameJSON(b, r) {
+		return
+	}
+	switch {
+	case len(r) == 0:
+		d.add(false, "no longer requires authentication")
+	case len(b) == 0:
+		d.add(true, "now requires authentication")
+	default:
+		breaking := false
+		for _, requirement := range b {
+			if !slices.ContainsFunc(r, func(candidate openapi3.SecurityRequirement) bool {
+				return satisfies(requirement, candidate)
+			}) {
+				d.add(true, "security requirement %s is no longer accepted", describeRequirement(requirement))
+				breaking = true
+			}
+		}
+		if !breaking {
+			d.add(false, "security requirements changed")
+		}
+	}
+}
+
+// satisfies reports whether every credential that meets have also meets want
+func satisfies(have, want openapi3.SecurityRequirement) bool {
+	for scheme, scopes := range want {
+		granted, ok := have[scheme]
+		if !ok {
+			return false
+		}
+		for _, scope := range scopes {
+			if !slices.Contains(granted, scope) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// describeRequirement formats a security requirement such as bearerAuth(products:read)
+func describeRequirement(requirement openapi3.SecurityRequirement) string {
+	schemes := make([]string, 0, len(requirement))
+	for _, scheme := range slices.Sorted(maps.Keys(requirement)) {
+		schemes = append(schemes, scheme+"("+strings.Join(requirement[scheme], " ")+")")
+	}
+	return strings.Join(schemes, " + ")
+}
+
+// parameters returns the parameters of an operation, including those of its
+// path item, keyed by location and name
+func parameters(op *Operation) map[string]*openapi3.Parameter {
+	params := make(map[string]*openapi3.Parameter)
+	for _, list := range []openapi3.Parameters{op.PathItem.Parameters, op.Operation.Parameters} {
+		for _, ref := range list {
+			if ref != nil && ref.Value != nil {
+				params[ref.Value.In+" parameter "+ref.Value.Name] = ref.Value
+			}
+		}
+	}
+	return params
+}
+
+// parameters compares the parameters of an operation
+func (d *differ) parameters(b, r map[string]*openapi3.Parameter) {
+	for _, key := range slices.Sorted(maps.Keys(b)) {
+		rp, ok := r[key]
+		if !ok {
+			d.add(false, "%s removed", key)
+			continue
+		}
+		if rp.Required && !b[key].Required {
+			d.add(true, "%s became required", key)
+		}
+		d.schema(true, key, b[key].Schema, rp.Schema)
+	}
+	for _, key := range slices.Sorted(maps.Keys(r)) {
+		if _, ok := b[key]; !ok {
+			if r[key].Required {
+				d.add(true, "required %s added", key)
+			} else {
+				d.add(false, "%s added", key)
+			}
+		}
+	}
+}
+
+// requestBody compares the request body of an operation
+func (d *differ) requestBody(b, r *openapi3.RequestBodyRef) {
+	var bv, rv *openapi3.RequestBody
+	if b != nil {
+		bv = b.Value
+	}
+	if r != nil {
+		rv = r.Value
+	}
+
+	switch {
+	case bv == nil && rv == nil:
+	case bv == nil:
+		d.add(rv.Required, "request body added")
+	case rv == nil:
+		d.add(false, "request body removed")
+	default:
+		if rv.Required && !bv.Required {
+			d.add(true, "request body became required")
+		}
+		d.content(true, "request body", bv.Content, rv.Content)
+	}
+}
+
+// responses compares the responses of an operation. Removing a success
+// response breaks clients; error responses are informational.
+func (d *differ) responses(b, r *openapi3.Responses) {
+	bm, rm := b.Map(), r.Map()
+	for _, status := range slices.Sorted(maps.Keys(bm)) {
+		loc := "response " + status
+		rr, ok := rm[status]
+		if !ok {
+			d.add(strings.HasPrefix(status, "2"), "%s removed", loc)
+			continue
+		}
+		if bm[status].Value != nil && rr.Value != nil {
+			d.content(false, loc, bm[status].Value.Content, rr.Value.Content)
+		}
+	}
+	for _, status := range slices.Sorted(maps.Keys(rm)) {
+		if _, ok := bm[status]; !ok {
+			d.add(false, "response %s added", status)
+		}
+	}
+}
+
+// content compares the media types of a request body or response
+func (d *differ) content(request bool, loc string, b, r openapi3.Content) {
+	for _, mediaType := range slices.Sorted(maps.Keys(b)) {
+		rm, ok := r[mediaType]
+		if !ok {
+			d.add(true, "%s no longer supports %s", loc, mediaType)
+			continue
+		}
+		d.schema(request, loc, b[mediaType].Schema, rm.Schema)
+	}
+	for _, mediaType := range slices.Sorted(maps.Keys(r)) {
+		if _, ok := b[mediaType]; !ok {
+			d.add(false, "%s supports %s", loc, mediaType)
+		}
+	}
+}
+
+// schema compares the schema at loc. In requests, constraints that reject
+// values accepted before break clients; in responses, values that could not
+// be returned before do.
+func (d *differ) schema(request bool, loc string, b, r *openapi3.SchemaRef) {
+	if b == nil || r == nil || b.Value == nil || r.Value == nil {
+		return
+	}
+	bs, rs := b.Value, r.Value
+	if d.seen[[2]*openapi3.Schema{bs, rs}] {
+		return
+	}
+	d.seen[[2]*openapi3.Schema{bs, rs}] = true
+
+	if !sameJSON(bs.Type, rs.Type) {
+		d.add(true, "%s: type changed from %s to %s", loc, typeName(bs), typeName(rs))
+		return
+	}
+	if bs.Format != rs.Format {
+		d.add(true, "%s: format changed from %q to %q", loc, bs.Format, rs.Format)
+	}
+	if bs.Nullable != rs.Nullable {
+		if rs.Nullable {
+			d.add(!request, "%s: became nullable", loc)
+		} else {
+			d.add(request, "%s: no longer nullable", loc)
+		}
+	}
+	if bs.Pattern != rs.Pattern {
+		d.add(request && rs.Pattern != "", "%s: pattern changed from %q to %q", loc, bs.Pattern, rs.Pattern)
+	}
+	d.enum(request, loc, bs.Enum, rs.Enum)
+
+	d.bound(request, loc, "maximum", bs.Max, rs.Max, true)
+	d.bound(request, loc, "minimum", bs.Min, rs.Min, false)
+	d.bound(request, loc, "maxLength", upperBound(bs.MaxLength), upperBound(rs.MaxLength), true)
+	d.bound(request, loc, "minLength", lowerBound(bs.MinLength), lowerBound(rs.MinLength), false)
+	d.bound(request, loc, "maxItems", upperBound(bs.MaxItems), upperBound(rs.MaxItems), true)
+	d.bound(request, loc, "minItems", lowerBound(bs.MinItems), lowerBound(rs.MinItems), false)
+
+	d.properties(request, loc, bs, rs)
+	if bs.Items != nil {
+		d.schema(request, loc+"[]", bs.Items, rs.Items)
+	}
+}
+
+// properties compares the properties of two object schemas, including
+// those contributed by allOf
+func (d *differ) properties(request bool, loc string, bs, rs *openapi3.Schema) {
+	bProps, bRequired := flatten(bs)
+	rProps, rRequired := flatten(rs)
+
+	for _, name := range slices.Sorted(maps.Keys(bProps)) {
+		prop := property(loc, name)
+		rp, ok := rProps[name]
+		if !ok {
+			d.add(!request, "%s removed", prop)
+			continue
+		}
+		wasRequired, isRequired := slices.Contains(bRequired, name), slices.Contains(rRequired, name)
+		switch {
+		case !wasRequired && isRequired:
+			d.add(request, "%s became required", prop)
+		case wasRequired && !isRequired:
+			d.add(!request, "%s is no longer required", prop)
+		}
+		d.schema(request, prop, bProps[name], rp)
+	}
+	for _, name := range slices.Sorted(maps.Keys(rProps)) {
+		if _, ok := bProps[name]; !ok {
+			if request && slices.Contains(rRequired, name) {
+				d.add(true, "required %s added", property(loc, name))
+			} else {
+				d.add(false, "%s added", property(loc, name))
+			}
+		}
+	}
+}
+
+// enum compares the allowed values of a schema
+func (d *differ) enum(request bool, loc string, b, r []any) {
+	switch {
+	case len(b) == 0 && len(r) == 0:
+	case len(b) == 0:
+		d.add(request, "%s: restricted to %v", loc, r)
+	case len(r) == 0:
+		d.add(!request, "%s: no longer restricted to %v", loc, b)
+	default:
+		for _, value := range b {
+			if !containsValue(r, value) {
+				d.add(request, "%s: value %v removed", loc, value)
+			}
+		}
+		for _, value := range r {
+			if !containsValue(b, value) {
+				d.add(!request, "%s: value %v added", loc, value)
+			}
+		}
+	}
+}
+
+// bound compares a numeric constraint. Upper bounds tighten when lowered,
+// lower bounds when raised.
+func (d *differ) bound(request bool, loc, name string, b, r *float64, upper bool) {
+	if b == nil && r == nil || b != nil && r != nil && *b == *r {
+		return
+	}
+	tightened := r != nil && (b == nil || upper && *r < *b || !upper && *r > *b)
+
+	switch {
+	case b == nil:
+		d.add(request == tightened, "%s: %s %v added", loc, name, *r)
+	case r == nil:
+		d.add(request == tightened, "%s: %s %v removed", loc, name, *b)
+	default:
+		d.add(request == tightened, "%s: %s changed from %v to %v", loc, name, *b, *r)
+	}
+}
+
+// flatten returns the properties and required properties of a schema
+// together with those of its allOf schemas
+func flatten(s *openapi3.Schema) (openapi3.Schemas, []string) {
+	props := make(openapi3.Schemas, len(s.Properties))
+	maps.Copy(props, s.Properties)
+	required := slices.Clone(s.Required)
+	for _, sub := range s.AllOf {
+		if sub != nil && sub.Value != nil {
+			subProps, subRequired := flatten(sub.Value)
+			maps.Copy(props, subProps)
+			required = append(required, subRequired...)
+		}
+	}
+	return props, required
+}
+
+// property returns the location of a property of the schema at loc
+func property(loc, name string) string {
+	if strings.Contains(loc, " property ") {
+		return loc + "." + name
+	}
+	return loc + " property " + name
+}
+
+// typeName formats the types of a schema
+func typeName(s *openapi3.Schema) string {
+	if s.Type == nil || len(*s.Type) == 0 {
+		return "any"
+	}
+	return strings.Join(s.Type.Slice(), "|")
+}
+
+// upperBound converts an optional upper bound
+func upperBound(v *uint64) *float64 {
+	if v == nil {
+		return nil
+	}
+	f := float64(*v)
+	return &f
+}
+
+// lowerBound converts a lower bound whose zero value means unset
+func lowerBound(v uint64) *float64 {
+	if v == 0 {
+		return nil
+	}
+	f := float64(v)
+	return &f
+}
+
+// containsValue reports whether values contains value
+func containsValue(values []any, value any) bool {
+	return slices.ContainsFunc(values, func(v any) bool { return sameJSON(v, value) })
+}
@@ -0,0 +1,132 @@
+package apispec_test
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"oapi-codegen-layout/internal/apispec"
+)
+
+// itemSpec builds a spec with an endpoint creating items; the fragments are
+// inserted into the request body schema, the response schema and the
+// parameters of the operation
+func itemSpec(t *testing.T, version, request, response, params string) *openapi3.T {
+	t.Helper()
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: 3.0.3
+info: {title: Items, version: '` + version + `'}
+paths:
+  /items/{id}:
+    put:
+      operationId: updateItem
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}` + params + `
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name: {type: string` + request + `}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  id: {type: string}` + response + `
+`))
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	return spec
+}
+
+func TestDiffClassifiesChanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     *openapi3.T
+		revision *openapi3.T
+		breaking bool
+	}{
+		{
+			name:     "tightened request constraint",
+			base:     itemSpec(t, "1.0.0", ", maxLength: 200", "", ""),
+			revision: itemSpec(t, "1.0.0", ", maxLength: 100", "", ""),
+			breaking: true,
+		},
+		{
+			name:     "loosened request constraint",
+			base:     itemSpec(t, "1.0.0", ", maxLength: 100", "", ""),
+			revision: itemSpec(t, "1.0.0", ", maxLength: 200", "", ""),
+		},
+		{
+			name:     "new optional parameter",
+			base:     itemSpec(t, "1.0.0", "", "", ""),
+			revision: itemSpec(t, "1.0.0", "", "", "\n        - {name: dryRun, in: query, schema: {type: boolean}}"),
+		},
+		{
+			name:     "new required parameter",
+			base:     itemSpec(t, "1.0.0", "", "", ""),
+			revision: itemSpec(t, "1.0.0", "", "", "\n        - {name: dryRun, in: query, required: true, schema: {type: boolean}}"),
+			breaking: true,
+		},
+		{
+			name:     "removed response property",
+			base:     itemSpec(t, "1.0.0", "", "\n                  name: {type: string}", ""),
+			revision: itemSpec(t, "1.0.0", "", "", ""),
+			breaking: true,
+		},
+		{
+			name:     "added response property",
+			base:     itemSpec(t, "1.0.0", "", "", ""),
+			revision: itemSpec(t, "1.0.0", "", "\n                  name: {type: string}", ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := apispec.Diff(tt.base, tt.revision)
+			if len(changes) != 1 {
+				t.Fatalf("expected one change, got %v", changes)
+			}
+			if changes[0].Breaking != tt.breaking {
+				t.Errorf("expected breaking=%v, got %s", tt.breaking, changes[0])
+			}
+		})
+	}
+}
+
+func TestDiffIgnoresIdenticalSpecs(t *testing.T) {
+	if changes := apispec.Diff(itemSpec(t, "1.0.0", "", "", ""), itemSpec(t, "1.0.0", "", "", "")); len(changes) != 0 {
+		t.Fatalf("expected no changes, got %v", changes)
+	}
+}
+
+func TestVersionBumped(t *testing.T) {
+	tests := []struct {
+		base, revision string
+		bumped         bool
+	}{
+		{"1.0.0", "1.0.0", false},
+		{"1.0.0", "1.0.1", true},
+		{"1.9.0", "1.10.0", true},
+		{"2.0.0", "1.9.9", false},
+		{"1.0", "1.0.0", true},
+	}
+	for _, tt := range tests {
+		bumped, err := apispec.VersionBumped(
+			itemSpec(t, tt.base, "", "", ""),
+			itemSpec(t, tt.revision, "", "", ""),
+		)
+		if err != nil {
+			t.Fatalf("VersionBumped(%s, %s): %v", tt.base, tt.revision, err)
+		}
+		if bumped != tt.bumped {
+			t.Errorf("VersionBumped(%s, %s) = %v, want %v", tt.base, tt.revision, bumped, tt.bumped)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package apispec
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadFile loads a split spec from disk and resolves its external
+// references. Shared schema files such as ../../schemas/Role.yaml become the
+// component named after the file, so every spec that declares the component
+// refers to the same definition.
+func LoadFile(filename string) (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	spec, err := loader.LoadFromFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", filename, err)
+	}
+	spec.InternalizeRefs(context.Background(), componentName)
+	return spec, nil
+}
+
+// componentName names an internalized component after the last element of
+// its JSON pointer, or after its file when it refers to a whole file
+func componentName(_ *openapi3.T, ref openapi3.ComponentRef) string {
+	file, pointer, _ := strings.Cut(ref.RefString(), "#")
+	if pointer != "" {
+		return path.Base(pointer)
+	}
+	return strings.TrimSuffix(path.Base(file), path.Ext(file))
+}
+
+// EncodeYAML encodes a spec as YAML with two-space indentation
+func EncodeYAML(spec *openapi3.T) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(spec); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,36 @@
+package apispec_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"oapi-codegen-layout/internal/apispec"
+)
+
+func TestLoadFileInternalizesSharedSchemas(t *testing.T) {
+	spec, err := apispec.LoadFile("../../api/specs/users/api.yaml")
+	if err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+
+	// Role is referenced from the component and from User.yaml; both must
+	// end up as the Role component
+	role := spec.Components.Schemas["User"].Value.Properties["role"]
+	if role.Ref != "#/components/schemas/Role" {
+		t.Fatalf("expected User.role to refer to the Role component, got %q", role.Ref)
+	}
+	for name := range spec.Components.Schemas {
+		if strings.Contains(name, "schemas") {
+			t.Errorf("unexpected internalized component %s", name)
+		}
+	}
+
+	encoded, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("failed to encode spec: %v", err)
+	}
+	if strings.Contains(string(encoded), ".yaml") {
+		t.Fatal("expected no references to external files")
+	}
+}
@@ -0,0 +1,157 @@
+// Package apispec maps the Gin routes registered by the generated
+// RegisterHandlers functions back to the OpenAPI operations they came from,
+// and merges the specs of all API domains into a single document.
+package apispec
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gin-gonic/gin"
+)
+
+// pathParamPattern matches OpenAPI path parameters such as {userId}
+var pathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// Operation is an OpenAPI operation together with the spec and path it belongs to
+type Operation struct {
+	Spec      *openapi3.T
+	Path      string
+	PathItem  *openapi3.PathItem
+	Method    string
+	Operation *openapi3.Operation
+}
+
+// ID returns the operationId of the operation
+func (o *Operation) ID() string {
+	return o.Operation.OperationID
+}
+
+// Security returns the security requirements of the operation, falling back
+// to the top-level requirements of its spec. Nil means the operation is public.
+func (o *Operation) Security() openapi3.SecurityRequirements {
+	if o.Operation.Security != nil {
+		return *o.Operation.Security
+	}
+	return o.Spec.Security
+}
+
+// SecurityScheme returns the security scheme with the given name from the spec of the operation
+func (o *Operation) SecurityScheme(name string) (*openapi3.SecurityScheme, bool) {
+	if o.Spec.Components == nil {
+		return nil, false
+	}
+	ref, ok := o.Spec.Components.SecuritySchemes[name]
+	if !ok || ref == nil || ref.Value == nil {
+		return nil, false
+	}
+	return ref.Value, true
+}
+
+// TimeoutExtension is the operation extension that sets the deadline of its
+// requests as a Go duration string, such as "5s"
+const TimeoutExtension = "x-timeout"
+
+// Timeout returns the deadline set with the x-timeout extension of the
+// operation; ok is false when the extension is absent
+func (o *Operation) Timeout() (timeout time.Duration, ok bool, err error) {
+	value, ok := o.Operation.Extensions[TimeoutExtension]
+	if !ok {
+		return 0, false, nil
+	}
+	s, isString := value.(string)
+	if isString {
+		timeout, err = time.ParseDuration(s)
+	}
+	if !isString || err != nil || timeout <= 0 {
+		return 0, false, fmt.Errorf("operation %s: %s must be a positive duration such as \"5s\", got %v",
+			o.ID(), TimeoutExtension, value)
+	}
+	return timeout, true, nil
+}
+
+// Route returns the operation as a kin-openapi route for openapi3filter
+func (o *Operation) Route() *routers.Route {
+	return &routers.Route{
+		Spec:      o.Spec,
+		Path:      o.Path,
+		PathItem:  o.PathItem,
+		Method:    o.Method,
+		Operation: o.Operation,
+	}
+}
+
+// Index resolves Gin routes to OpenAPI operations
+type Index struct {
+	operations map[string]*Operation
+}
+
+// NewIndex indexes every operation of the given specs as registered under basePath
+func NewIndex(basePath string, specs ...*openapi3.T) *Index {
+	idx := &Index{operations: make(map[string]*Operation)}
+	for _, spec := range specs {
+		if spec.Paths == nil {
+			continue
+		}
+		for path, pathItem := range spec.Paths.Map() {
+			ginPath := basePath + pathParamPattern.ReplaceAllString(path, ":$1")
+			for method, operation := range pathItem.Operations() {
+				idx.operations[routeKey(method, ginPath)] = &Operation{
+					Spec:      spec,
+					Path:      path,
+					PathItem:  pathItem,
+					Method:    method,
+					Operation: operation,
+				}
+			}
+		}
+	}
+	return idx
+}
+
+// Lookup returns the operation registered for a method and Gin route pattern
+func (i *Index) Lookup(method, ginPath string) (*Operation, bool) {
+	op, ok := i.operations[routeKey(method, ginPath)]
+	return op, ok
+}
+
+// Match returns the operation handling the current request
+func (i *Index) Match(c *gin.Context) (*Operation, bool) {
+	return i.Lookup(c.Request.Method, c.FullPath())
+}
+
+// Operations returns every indexed operation
+func (i *Index) Operations() []*Operation {
+	ops := make([]*Operation, 0, len(i.operations))
+	for _, op := range i.operations {
+		ops = append(ops, op)
+	}
+	return ops
+}
+
+// Scopes returns every scope required by an indexed operation, sorted
+func (i *Index) Scopes() []string {
+	var scopes []string
+	for _, op := range i.operations {
+		for _, requirement := range op.Security() {
+			for _, required := range requirement {
+				for _, scope := range required {
+					if !slices.Contains(scopes, scope) {
+						scopes = append(scopes, scope)
+					}
+				}
+			}
+		}
+	}
+	slices.Sort(scopes)
+	return scopes
+}
+
+// routeKey builds the lookup key for a method and Gin route pattern
+func routeKey(method, ginPath string) string {
+	return method + " " + ginPath
+}
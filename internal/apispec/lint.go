@@ -0,0 +1,219 @@
+package apispec
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Lint rules
+const (
+	RuleOperationID         = "operation-id"
+	RuleErrorResponse       = "error-response"
+	RulePagination          = "pagination"
+	RulePropertyDescription = "property-description"
+)
+
+// ErrorSchema is the component every operation must document as a response
+const ErrorSchema = "Error"
+
+// PaginationParams are the query parameters every list operation must accept
+var PaginationParams = []string{"limit", "cursor"}
+
+// camelCasePattern matches camelCase identifiers such as getUserById
+var camelCasePattern = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+
+// Finding is a violation of a project convention
+type Finding struct {
+	Rule string `json:"rule"`
+	// Domain is the spec the violation was found in
+	Domain string `json:"domain"`
+	// Location is the operation or schema, such as "GET /users" or
+	// "schema User property email"
+	Location string `json:"location"`
+	Message  string `json:"message"`
+}
+
+// String formats the finding as a single line
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s: %s (%s)", f.Domain, f.Location, f.Message, f.Rule)
+}
+
+// Lint checks the specs of domains against the conventions of the project:
+// operationIds are camelCase and unique across domains, every operation
+// documents an Error response, list operations accept the pagination
+// parameters and every schema property has a description. Findings about
+// schemas shared by several domains are reported once.
+func Lint(domains []Domain) []Finding {
+	l := &linter{
+		operationIDs: make(map[string]string),
+		reported:     make(map[string]bool),
+	}
+	for _, domain := range domains {
+		l.domain = domain.Name
+		l.seen = make(map[*openapi3.Schema]bool)
+		l.lintSpec(domain.Spec)
+	}
+	return l.findings
+}
+
+// linter collects the findings of all domains
+type linter struct {
+	// domain is the name of the domain being linted
+	domain string
+	// operationIDs maps lower-cased operationIds to their domain
+	operationIDs map[string]string
+	// reported holds the findings already recorded, keyed by rule, location
+	// and message
+	reported map[string]bool
+	// seen holds the schemas of the current domain already checked
+	seen     map[*openapi3.Schema]bool
+	findings []Finding
+}
+
+// add records a finding unless an identical one was already reported
+func (l *linter) add(rule, location, format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	key := rule + "\x00" + location + "\x00" + message
+	if l.reported[key] {
+		return
+	}
+	l.reported[key] = true
+	l.findings = append(l.findings, Finding{
+		Rule:     rule,
+		Domain:   l.domain,
+		Location: location,
+		Message:  message,
+	})
+}
+
+// lintSpec checks the operations and component schemas of a spec
+func (l *linter) lintSpec(spec *openapi3.T) {
+	if spec.Components != nil {
+		for _, name := range slices.Sorted(maps.Keys(spec.Components.Schemas)) {
+			l.schema("schema "+name, spec.Components.Schemas[name])
+		}
+	}
+	if spec.Paths == nil {
+		return
+	}
+
+	for _, p := range spec.Paths.InMatchingOrder() {
+		operations := spec.Paths.Value(p).Operations()
+		for _, method := range slices.Sorted(maps.Keys(operations)) {
+			operation := operations[method]
+			location := method + " " + p
+			l.operationID(location, operation.OperationID)
+			l.errorResponse(location, operation)
+			l.pagination(location, method, spec.Paths.Value(p), operation)
+
+			if body := operation.RequestBody; body != nil && body.Value != nil {
+				for _, mediaType := range slices.Sorted(maps.Keys(body.Value.Content)) {
+					l.schema(location+" request body", body.Value.Content[mediaType].Schema)
+				}
+			}
+			responses := operation.Responses.Map()
+			for _, status := range slices.Sorted(maps.Keys(responses)) {
+				if response := responses[status].Value; response != nil {
+					for _, mediaType := range slices.Sorted(maps.Keys(response.Content)) {
+						l.schema(location+" response "+status, response.Content[mediaType].Schema)
+					}
+				}
+			}
+		}
+	}
+}
+
+// operationID checks that an operationId is camelCase and not used by
+// another operation, compared case-insensitively like the merged spec does
+func (l *linter) operationID(location, id string) {
+	if id == "" {
+		l.add(RuleOperationID, location, "operationId is missing")
+		return
+	}
+	if !camelCasePattern.MatchString(id) {
+		l.add(RuleOperationID, location, "operationId %s is not camelCase", id)
+	}
+	if owner, ok := l.operationIDs[strings.ToLower(id)]; ok {
+		l.add(RuleOperationID, location, "operationId %s is already used in %s", id, owner)
+		return
+	}
+	l.operationIDs[strings.ToLower(id)] = l.domain
+}
+
+// errorResponse checks that an operation documents a response with the
+// Error schema
+func (l *linter) errorResponse(location string, operation *openapi3.Operation) {
+	for _, response := range operation.Responses.Map() {
+		if response.Value == nil {
+			continue
+		}
+		for _, mediaType := range response.Value.Content {
+			if mediaType.Schema != nil && path.Base(mediaType.Schema.Ref) == ErrorSchema {
+				return
+			}
+		}
+	}
+	l.add(RuleErrorResponse, location, "no response documents the %s schema", ErrorSchema)
+}
+
+// pagination checks that a list operation, a GET returning an array, accepts
+// the pagination query parameters
+func (l *linter) pagination(location, method string, item *openapi3.PathItem, operation *openapi3.Operation) {
+	if method != http.MethodGet || !returnsArray(operation) {
+		return
+	}
+	for _, name := range PaginationParams {
+		if operation.Parameters.GetByInAndName(openapi3.ParameterInQuery, name) == nil &&
+			item.Parameters.GetByInAndName(openapi3.ParameterInQuery, name) == nil {
+			l.add(RulePagination, location, "list operation lacks the %s query parameter", name)
+		}
+	}
+}
+
+// returnsArray reports whether a success response of an operation is an array
+func returnsArray(operation *openapi3.Operation) bool {
+	for status, response := range operation.Responses.Map() {
+		if !strings.HasPrefix(status, "2") || response.Value == nil {
+			continue
+		}
+		for _, mediaType := range response.Value.Content {
+			if mediaType.Schema != nil && mediaType.Schema.Value != nil && mediaType.Schema.Value.Type.Is(openapi3.TypeArray) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// schema checks that every property of a schema and of the schemas it
+// contains has a description. Referenced components are checked under their
+// own name.
+func (l *linter) schema(location string, ref *openapi3.SchemaRef) {
+	if ref == nil || ref.Value == nil || l.seen[ref.Value] {
+		return
+	}
+	l.seen[ref.Value] = true
+	if ref.Ref != "" {
+		location = "schema " + path.Base(ref.Ref)
+	}
+	s := ref.Value
+
+	for _, name := range slices.Sorted(maps.Keys(s.Properties)) {
+		prop := s.Properties[name]
+		if prop.Value != nil && prop.Value.Description == "" {
+			l.add(RulePropertyDescription, property(location, name), "property has no description")
+		}
+		l.schema(property(location, name), prop)
+	}
+	l.schema(location+"[]", s.Items)
+	for _, sub := range slices.Concat(s.AllOf, s.OneOf, s.AnyOf) {
+		l.schema(location, sub)
+	}
+}
@@ -0,0 +1,115 @@
+package apispec_test
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"oapi-codegen-layout/internal/apispec"
+)
+
+// lintSpec builds a spec with a list operation; the fragments are inserted
+// into its parameters, its responses and the properties of the Item schema
+func lintSpec(t *testing.T, operationID, params, responses, properties string) *openapi3.T {
+	t.Helper()
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: 3.0.3
+info: {title: Items, version: 1.0.0}
+paths:
+  /items:
+    get:
+      operationId: ` + operationID + `
+      parameters:` + params + `
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items: {$ref: "#/components/schemas/Item"}` + responses + `
+components:
+  schemas:
+    Error:
+      type: object
+      properties:
+        code: {type: string, description: Error code}
+    Item:
+      type: object
+      properties:
+        id: {type: string, description: Identifier}` + properties + `
+`))
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	return spec
+}
+
+const (
+	paginationParams = `
+        - {name: limit, in: query, schema: {type: integer}}
+        - {name: cursor, in: query, schema: {type: string}}`
+	errorResponse = `
+        "500":
+          description: Internal server error
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Error"}`
+)
+
+func TestLintAcceptsConventionalSpec(t *testing.T) {
+	spec := lintSpec(t, "listItems", paginationParams, errorResponse, "")
+	if findings := apispec.Lint([]apispec.Domain{{Name: "items", Spec: spec}}); len(findings) != 0 {
+		t.Fatalf("expected no findings, got %v", findings)
+	}
+}
+
+func TestLintReportsViolations(t *testing.T) {
+	tests := []struct {
+		name    string
+		domains []apispec.Domain
+		rule    string
+	}{
+		{
+			name:    "operationId not camelCase",
+			domains: []apispec.Domain{{Name: "items", Spec: lintSpec(t, "list_items", paginationParams, errorResponse, "")}},
+			rule:    apispec.RuleOperationID,
+		},
+		{
+			name: "duplicate operationId",
+			domains: []apispec.Domain{
+				{Name: "items", Spec: lintSpec(t, "listItems", paginationParams, errorResponse, "")},
+				{Name: "more", Spec: lintSpec(t, "ListItems", paginationParams, errorResponse, "")},
+			},
+			rule: apispec.RuleOperationID,
+		},
+		{
+			name:    "missing Error response",
+			domains: []apispec.Domain{{Name: "items", Spec: lintSpec(t, "listItems", paginationParams, "", "")}},
+			rule:    apispec.RuleErrorResponse,
+		},
+		{
+			name:    "missing pagination",
+			domains: []apispec.Domain{{Name: "items", Spec: lintSpec(t, "listItems", " []", errorResponse, "")}},
+			rule:    apispec.RulePagination,
+		},
+		{
+			name:    "property without description",
+			domains: []apispec.Domain{{Name: "items", Spec: lintSpec(t, "listItems", paginationParams, errorResponse, "\n        name: {type: string}")}},
+			rule:    apispec.RulePropertyDescription,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := apispec.Lint(tt.domains)
+			if len(findings) == 0 {
+				t.Fatal("expected findings")
+			}
+			for _, finding := range findings {
+				if finding.Rule != tt.rule {
+					t.Errorf("expected only %s findings, got %s", tt.rule, finding)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package apispec
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Loader decodes the OpenAPI spec of a domain, such as the GetSwagger
+// function of a generated package
+type Loader func() (*openapi3.T, error)
+
+// Domain is the decoded OpenAPI spec of one API domain
+type Domain struct {
+	Name string
+	Spec *openapi3.T
+}
+
+// Registry collects the specs of the API domains so they can be decoded and
+// merged once at startup
+type Registry struct {
+	names   []string
+	loaders []Loader
+}
+
+// NewRegistry creates an empty spec registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds the spec of a domain. Domains are loaded and merged in
+// registration order.
+func (r *Registry) Register(name string, load Loader) {
+	r.names = append(r.names, name)
+	r.loaders = append(r.loaders, load)
+}
+
+// Names returns the names of the registered domains in registration order
+func (r *Registry) Names() []string {
+	return slices.Clone(r.names)
+}
+
+// Load decodes the spec of every registered domain
+func (r *Registry) Load() ([]Domain, error) {
+	domains := make([]Domain, len(r.names))
+	for i, name := range r.names {
+		if slices.Contains(r.names[:i], name) {
+			return nil, fmt.Errorf("OpenAPI spec of domain %s is registered twice", name)
+		}
+		spec, err := r.loaders[i]()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s OpenAPI spec: %w", name, err)
+		}
+		domains[i] = Domain{Name: name, Spec: spec}
+	}
+	return domains, nil
+}
+
+// Merge combines the servers, paths, tags and components of domains into a
+// single document described by info. A path may be split across domains as
+// long as every method is defined once; components and tags may only be
+// defined by several domains when the definitions are identical. Every
+// conflict is reported in the returned error, and the result is validated.
+func Merge(info *openapi3.Info, domains []Domain) (*openapi3.T, error) {
+	merged := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info:    info,
+		Paths:   openapi3.NewPaths(),
+		Components: &openapi3.Components{
+			Schemas:         make(openapi3.Schemas),
+			Parameters:      make(openapi3.ParametersMap),
+			Headers:         make(openapi3.Headers),
+			RequestBodies:   make(openapi3.RequestBodies),
+			Responses:       make(openapi3.ResponseBodies),
+			SecuritySchemes: make(openapi3.SecuritySchemes),
+			Examples:        make(openapi3.Examples),
+		},
+	}
+
+	m := merger{
+		merged:       merged,
+		owners:       make(map[string]string),
+		operationIDs: make(map[string]string),
+	}
+	for _, domain := range domains {
+		m.mergeDomain(domain)
+	}
+	if len(m.conflicts) > 0 {
+		return nil, fmt.Errorf("conflicting OpenAPI definitions: %w", errors.Join(m.conflicts...))
+	}
+	if err := merged.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("merged OpenAPI spec is invalid: %w", err)
+	}
+	return merged, nil
+}
+
+// merger accumulates domains into a merged document
+type merger struct {
+	merged *openapi3.T
+	// owners maps the key of every merged definition to its first domain
+	owners map[string]string
+	// operationIDs maps lower-cased operationIds to their domain; they are
+	// compared case-insensitively like the authorization policy does
+	operationIDs map[string]string
+	conflicts    []error
+}
+
+// mergeDomain adds the definitions of a single domain
+func (m *merger) mergeDomain(domain Domain) {
+	spec := domain.Spec
+	for _, server := range spec.Servers {
+		if !slices.ContainsFunc(m.merged.Servers, func(s *openapi3.Server) bool { return s.URL == server.URL }) {
+			m.merged.Servers = append(m.merged.Servers, server)
+		}
+	}
+
+	for _, tag := range spec.Tags {
+		i := slices.IndexFunc(m.merged.Tags, func(t *openapi3.Tag) bool { return t.Name == tag.Name })
+		if i < 0 {
+			m.merged.Tags = append(m.merged.Tags, tag)
+			m.owners["tag "+tag.Name] = domain.Name
+			continue
+		}
+		if !sameJSON(m.merged.Tags[i], tag) {
+			m.conflict(domain.Name, "tag "+tag.Name)
+		}
+	}
+
+	if spec.Paths != nil {
+		for _, path := range spec.Paths.InMatchingOrder() {
+			m.mergePath(domain, path, spec.Paths.Value(path))
+		}
+	}
+
+	if c := spec.Components; c != nil {
+		mergeComponents(m, domain.Name, "schema", m.merged.Components.Schemas, c.Schemas)
+		mergeComponents(m, domain.Name, "parameter", m.merged.Components.Parameters, c.Parameters)
+		mergeComponents(m, domain.Name, "header", m.merged.Components.Headers, c.Headers)
+		mergeComponents(m, domain.Name, "request body", m.merged.Components.RequestBodies, c.RequestBodies)
+		mergeComponents(m, domain.Name, "response", m.merged.Components.Responses, c.Responses)
+		mergeComponents(m, domain.Name, "security scheme", m.merged.Components.SecuritySchemes, c.SecuritySchemes)
+		mergeComponents(m, domain.Name, "example", m.merged.Components.Examples, c.Examples)
+	}
+}
+
+// mergePath adds the operations of a path. Top-level security requirements
+// of the domain are copied into its operations, since the merged document
+// has no top-level requirements.
+func (m *merger) mergePath(domain Domain, path string, item *openapi3.PathItem) {
+	existing := m.merged.Paths.Value(path)
+	if existing == nil {
+		existing = &openapi3.PathItem{
+			Summary:     item.Summary,
+			Description: item.Description,
+			Parameters:  item.Parameters,
+		}
+		m.merged.Paths.Set(path, existing)
+		m.owners["parameters of path "+path] = domain.Name
+	} else if !sameJSON(existing.Parameters, item.Parameters) {
+		m.conflict(domain.Name, "parameters of path "+path)
+	}
+
+	operations := item.Operations()
+	for _, method := range slices.Sorted(maps.Keys(operations)) {
+		operation := operations[method]
+		key := "operation " + method + " " + path
+		if existing.GetOperation(method) != nil {
+			m.conflict(domain.Name, key)
+			continue
+		}
+		m.owners[key] = domain.Name
+
+		if id := strings.ToLower(operation.OperationID); id != "" {
+			if owner, ok := m.operationIDs[id]; ok {
+				m.conflicts = append(m.conflicts, fmt.Errorf("operationId %s of %s is already used by %s",
+					operation.OperationID, domain.Name, owner))
+			}
+			m.operationIDs[id] = domain.Name
+		}
+
+		if operation.Security == nil && domain.Spec.Security != nil {
+			copied := *operation
+			copied.Security = &domain.Spec.Security
+			operation = &copied
+		}
+		existing.SetOperation(method, operation)
+	}
+}
+
+// conflict records that domain redefines the definition with key
+func (m *merger) conflict(domain, key string) {
+	m.conflicts = append(m.conflicts, fmt.Errorf("%s of %s conflicts with the definition in %s",
+		key, domain, m.owners[key]))
+}
+
+// mergeComponents adds the components of one kind to dst, recording
+// conflicts for names that are already defined differently
+func mergeComponents[M ~map[string]V, V any](m *merger, domain, kind string, dst, src M) {
+	for _, name := range slices.Sorted(maps.Keys(src)) {
+		value := src[name]
+		key := kind + " " + name
+		existing, ok := dst[name]
+		if !ok {
+			dst[name] = value
+			m.owners[key] = domain
+			continue
+		}
+		if !sameJSON(existing, value) {
+			m.conflict(domain, key)
+		}
+	}
+}
+
+// sameJSON reports whether two definitions serialize to the same JSON
+func sameJSON(a, b any) bool {
+	aJSON, aErr := json.Marshal(a)
+	bJSON, bErr := json.Marshal(b)
+	return aErr == nil && bErr == nil && string(aJSON) == string(bJSON)
+}
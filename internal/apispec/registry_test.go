@@ -0,0 +1,85 @@
+package apispec_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"oapi-codegen-layout/internal/apispec"
+)
+
+// domainSpec builds a domain spec with a GET operation on path and a Name schema
+func domainSpec(t *testing.T, path, operationID, nameType string) *openapi3.T {
+	t.Helper()
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: 3.0.3
+info: {title: Domain, version: 1.0.0}
+paths:
+  ` + path + `:
+    get:
+      operationId: ` + operationID + `
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Name'}
+components:
+  schemas:
+    Name: {type: ` + nameType + `}
+`))
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	return spec
+}
+
+func TestMergeCombinesDomains(t *testing.T) {
+	registry := apispec.NewRegistry()
+	registry.Register("users", func() (*openapi3.T, error) { return domainSpec(t, "/users", "listUsers", "string"), nil })
+	registry.Register("products", func() (*openapi3.T, error) { return domainSpec(t, "/products", "listProducts", "string"), nil })
+	domains, err := registry.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	merged, err := apispec.Merge(&openapi3.Info{Title: "API", Version: "1.0.0"}, domains)
+	if err != nil {
+		t.Fatalf("expected identical shared schemas to merge, got %v", err)
+	}
+	if merged.Paths.Len() != 2 || merged.Components.Schemas["Name"] == nil {
+		t.Fatalf("expected both paths and the shared schema, got %v", merged.Paths.InMatchingOrder())
+	}
+}
+
+func TestMergeReportsConflicts(t *testing.T) {
+	domains := []apispec.Domain{
+		{Name: "users", Spec: domainSpec(t, "/users", "listUsers", "string")},
+		{Name: "products", Spec: domainSpec(t, "/users", "ListUsers", "integer")},
+	}
+
+	_, err := apispec.Merge(&openapi3.Info{Title: "API", Version: "1.0.0"}, domains)
+	if err == nil {
+		t.Fatal("expected conflicting domains to fail")
+	}
+	for _, want := range []string{
+		"operation GET /users of products conflicts with the definition in users",
+		"schema Name of products conflicts with the definition in users",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got %v", want, err)
+		}
+	}
+}
+
+func TestMergeRejectsDuplicateOperationIDs(t *testing.T) {
+	domains := []apispec.Domain{
+		{Name: "users", Spec: domainSpec(t, "/users", "listItems", "string")},
+		{Name: "products", Spec: domainSpec(t, "/products", "ListItems", "string")},
+	}
+
+	_, err := apispec.Merge(&openapi3.Info{Title: "API", Version: "1.0.0"}, domains)
+	if err == nil || !strings.Contains(err.Error(), "operationId ListItems of products is already used by users") {
+		t.Fatalf("expected duplicate operationId error, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+// Package auth verifies bearer tokens and exposes their claims to handlers.
+package auth
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// claimsKey is the gin.Context key under which the authenticated claims are stored
+const claimsKey = "auth.claims"
+
+// Claims are the claims of a verified access token
+type Claims struct {
+	jwt.RegisteredClaims
+
+	// Scope lists the granted scopes separated by spaces, as in RFC 8693
+	Scope string `json:"scope,omitempty"`
+	// Tenant is the tenant the caller belongs to; empty means tenant.Default
+	Tenant string `json:"tenant,omitempty"`
+
+	// apiKey is set by APIKeys.Verify; tokens cannot claim it
+	apiKey bool
+}
+
+// Scopes returns the granted scopes
+func (c *Claims) Scopes() []string {
+	return strings.Fields(c.Scope)
+}
+
+// HasScopes reports whether every one of the required scopes was granted
+func (c *Claims) HasScopes(required ...string) bool {
+	granted := c.Scopes()
+	for _, scope := range required {
+		if !slices.Contains(granted, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsAPIKey reports whether the caller authenticated with an API key rather
+// than a bearer token
+func (c *Claims) IsAPIKey() bool {
+	return c.apiKey
+}
+
+// SetClaims stores the authenticated claims in the request context
+func SetClaims(c *gin.Context, claims *Claims) {
+	c.Set(claimsKey, claims)
+}
+
+// ClaimsFromContext returns the claims of the authenticated caller, if any
+func ClaimsFromContext(c *gin.Context) (*Claims, bool) {
+	value, ok := c.Get(claimsKey)
+	if !ok {
+		return nil, false
+	}
+	claims, ok := value.(*Claims)
+	return claims, ok
+}
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+	"oapi-codegen-layout/internal/config"
+)
+
+// ErrInvalidToken is returned for tokens that are malformed, expired or not
+// signed by a configured key
+var ErrInvalidToken = errors.New("invalid bearer token")
+
+// publicKey is a verification key from the JWKS file
+type publicKey struct {
+	id  string
+	key crypto.PublicKey
+}
+
+// Verifier verifies bearer tokens signed with HS256, RS256 or ES256
+type Verifier struct {
+	secret []byte
+	keys   []publicKey
+	parser *jwt.Parser
+}
+
+// NewVerifier creates a verifier for the key sources in cfg
+func NewVerifier(cfg config.AuthConfig) (*Verifier, error) {
+	if !cfg.Enabled() {
+		return nil, fmt.Errorf("no bearer token key configured")
+	}
+
+	v := &Verifier{}
+	var methods []string
+	if cfg.JWTSecret != "" {
+		v.secret = []byte(cfg.JWTSecret)
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if cfg.JWKSFile != "" {
+		keys, err := loadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		v.keys = keys
+		methods = append(methods, jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg())
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.Audience))
+	}
+	v.parser = jwt.NewParser(options...)
+	return v, nil
+}
+
+// Verify checks the signature and registered claims of a token and returns its claims
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	claims := &Claims{}
+	if _, err := v.parser.ParseWithClaims(token, claims, v.key); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	return claims, nil
+}
+
+// key selects the verification keys for a token by algorithm and key ID.
+// Without a kid header every key of the matching type is tried.
+func (v *Verifier) key(token *jwt.Token) (any, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		return v.secret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	var candidates []jwt.VerificationKey
+	for _, k := range v.keys {
+		if kid != "" && k.id != kid {
+			continue
+		}
+		switch key := k.key.(type) {
+		case *rsa.PublicKey:
+			if _, ok := token.Method.(*jwt.SigningMethodRSA); ok {
+				candidates = append(candidates, key)
+			}
+		case *ecdsa.PublicKey:
+			if _, ok := token.Method.(*jwt.SigningMethodECDSA); ok {
+				candidates = append(candidates, key)
+			}
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf("no key found for kid %q", kid)
+	case 1:
+		return candidates[0], nil
+	default:
+		return jwt.VerificationKeySet{Keys: candidates}, nil
+	}
+}
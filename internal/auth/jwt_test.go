@@ -0,0 +1,162 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"oapi-codegen-layout/internal/config"
+)
+
+// sign creates a token with the given method, key, kid and claims
+func sign(t *testing.T, method jwt.SigningMethod, key any, kid string, claims Claims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+// validClaims returns claims that expire in an hour
+func validClaims(scope string) Claims {
+	return Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user-1",
+			Issuer:    "https://issuer.example.com",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Scope: scope,
+	}
+}
+
+// writeJWKS writes the public keys to a JWKS file and returns its path
+func writeJWKS(t *testing.T, keys map[string]crypto.PublicKey) string {
+	t.Helper()
+
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	for kid, key := range keys {
+		switch key := key.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, jsonWebKey{Kty: "RSA", Kid: kid, Use: "sig", N: encode(key.N.Bytes()), E: encode(big.NewInt(int64(key.E)).Bytes())})
+		case *ecdsa.PublicKey:
+			point, err := key.Bytes()
+			if err != nil {
+				t.Fatalf("failed to encode EC key: %v", err)
+			}
+			set.Keys = append(set.Keys, jsonWebKey{Kty: "EC", Kid: kid, Crv: "P-256", X: encode(point[1:33]), Y: encode(point[33:])})
+		}
+	}
+
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatalf("failed to encode JWKS: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write JWKS: %v", err)
+	}
+	return path
+}
+
+func TestVerifyHS256(t *testing.T) {
+	v, err := NewVerifier(config.AuthConfig{JWTSecret: "secret", Issuer: "https://issuer.example.com"})
+	if err != nil {
+		t.Fatalf("NewVerifier: %v", err)
+	}
+
+	claims, err := v.Verify(sign(t, jwt.SigningMethodHS256, []byte("secret"), "", validClaims("products:read products:write")))
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if claims.Subject != "user-1" || !claims.HasScopes("products:read", "products:write") || claims.HasScopes("users:read") {
+		t.Fatalf("unexpected claims %+v", claims)
+	}
+
+	expired := validClaims("")
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	wrongIssuer := validClaims("")
+	wrongIssuer.Issuer = "https://other.example.com"
+	noExpiry := validClaims("")
+	noExpiry.ExpiresAt = nil
+
+	for name, token := range map[string]string{
+		"wrong secret": sign(t, jwt.SigningMethodHS256, []byte("other"), "", validClaims("")),
+		"expired":      sign(t, jwt.SigningMethodHS256, []byte("secret"), "", expired),
+		"wrong issuer": sign(t, jwt.SigningMethodHS256, []byte("secret"), "", wrongIssuer),
+		"no expiry":    sign(t, jwt.SigningMethodHS256, []byte("secret"), "", noExpiry),
+		"malformed":    "not-a-token",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := v.Verify(token); !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("expected ErrInvalidToken, got %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+
+	path := writeJWKS(t, map[string]crypto.PublicKey{"rsa-1": &rsaKey.PublicKey, "ec-1": &ecKey.PublicKey})
+	v, err := NewVerifier(config.AuthConfig{JWKSFile: path})
+	if err != nil {
+		t.Fatalf("NewVerifier: %v", err)
+	}
+
+	valid := map[string]string{
+		"RS256 with kid":    sign(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", validClaims("")),
+		"RS256 without kid": sign(t, jwt.SigningMethodRS256, rsaKey, "", validClaims("")),
+		"ES256 with kid":    sign(t, jwt.SigningMethodES256, ecKey, "ec-1", validClaims("")),
+	}
+	for name, token := range valid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := v.Verify(token); err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+		})
+	}
+
+	invalid := map[string]string{
+		"unknown key":   sign(t, jwt.SigningMethodRS256, otherKey, "", validClaims("")),
+		"unknown kid":   sign(t, jwt.SigningMethodRS256, rsaKey, "rsa-2", validClaims("")),
+		"HS256 refused": sign(t, jwt.SigningMethodHS256, []byte("secret"), "", validClaims("")),
+	}
+	for name, token := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := v.Verify(token); !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("expected ErrInvalidToken, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Password length limits enforced by ValidatePassword
+const (
+	MinPasswordLength = 12
+	MaxPasswordLength = 128
+)
+
+// argon2id parameters for new hashes, following the OWASP recommendation.
+// Stored hashes record their own parameters, so these can be raised later.
+const (
+	argon2Memory  = 19 * 1024 // KiB
+	argon2Time    = 2
+	argon2Threads = 1
+	argon2KeyLen  = 32
+	argon2SaltLen = 16
+)
+
+// ErrWeakPassword is returned for passwords that do not meet the password policy
+var ErrWeakPassword = errors.New("password does not meet the policy")
+
+// dummyHash is checked against when a login names an unknown user, so that
+// the response time does not reveal which email addresses exist
+var dummyHash = sync.OnceValue(func() string {
+	hash, err := HashPassword("dummy password for unknown users")
+	if err != nil {
+		panic(err)
+	}
+	return hash
+})
+
+// ValidatePassword checks a new password against the password policy. The
+// returned error wraps ErrWeakPassword and explains the violated rule.
+func ValidatePassword(password, email string) error {
+	length := utf8.RuneCountInString(password)
+	if length < MinPasswordLength {
+		return fmt.Errorf("%w: it must be at least %d characters long", ErrWeakPassword, MinPasswordLength)
+	}
+	if length > MaxPasswordLength {
+		return fmt.Errorf("%w: it must be at most %d characters long", ErrWeakPassword, MaxPasswordLength)
+	}
+
+	var letters, others bool
+	for _, r := range password {
+		if unicode.IsLetter(r) {
+			letters = true
+		} else if !unicode.IsSpace(r) {
+			others = true
+		}
+	}
+	if !letters || !others {
+		return fmt.Errorf("%w: it must contain letters and digits or symbols", ErrWeakPassword)
+	}
+
+	if local, _, _ := strings.Cut(email, "@"); len(local) >= 3 &&
+		strings.Contains(strings.ToLower(password), strings.ToLower(local)) {
+		return fmt.Errorf("%w: it must not contain the email address", ErrWeakPassword)
+	}
+	return nil
+}
+
+// HashPassword hashes a password with argon2id and a random salt. The result
+// is encoded in the PHC string format and embeds the parameters used.
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, argon2Memory, argon2Time, argon2Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// CheckPassword reports whether password matches an encoded hash created by
+// HashPassword
+func CheckPassword(encoded, password string) (bool, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return false, fmt.Errorf("unsupported password hash format")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, fmt.Errorf("unsupported argon2 version %q", parts[2])
+	}
+	var memory, time uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return false, fmt.Errorf("invalid argon2 parameters %q: %w", parts[3], err)
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, fmt.Errorf("invalid argon2 salt: %w", err)
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false, fmt.Errorf("invalid argon2 hash: %w", err)
+	}
+
+	got := argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(want)))
+	return subtle.ConstantTimeCompare(got, want) == 1, nil
+}
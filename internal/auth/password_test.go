@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidatePassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		valid    bool
+	}{
+		{"valid", "correct-horse-42", true},
+		{"too short", "short-1", false},
+		{"too long", strings.Repeat("a1", 65), false},
+		{"letters only", "correcthorsebattery", false},
+		{"digits only", "123456789012345", false},
+		{"contains email", "Jane.Doe-2024!", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePassword(tt.password, "jane.doe@example.com")
+			if tt.valid && err != nil {
+				t.Fatalf("expected password to be valid, got %v", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrWeakPassword) {
+				t.Fatalf("expected ErrWeakPassword, got %v", err)
+			}
+		})
+	}
+}
+
+func TestHashPassword(t *testing.T) {
+	hash, err := HashPassword("correct-horse-42")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$") {
+		t.Fatalf("unexpected hash format %q", hash)
+	}
+
+	if ok, err := CheckPassword(hash, "correct-horse-42"); err != nil || !ok {
+		t.Fatalf("expected password to match, got %v, %v", ok, err)
+	}
+	if ok, err := CheckPassword(hash, "wrong-horse-42"); err != nil || ok {
+		t.Fatalf("expected password not to match, got %v, %v", ok, err)
+	}
+	if _, err := CheckPassword("$2a$10$bcrypt", "correct-horse-42"); err == nil {
+		t.Fatal("expected an error for an unsupported hash")
+	}
+
+	other, err := HashPassword("correct-horse-42")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+	if other == hash {
+		t.Fatal("expected hashes of the same password to use different salts")
+	}
+}
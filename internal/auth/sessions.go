@@ -0,0 +1,220 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"oapi-codegen-layout/internal/authz"
+	"oapi-codegen-layout/internal/config"
+	"oapi-codegen-layout/internal/models"
+	"oapi-codegen-layout/internal/repository"
+	"oapi-codegen-layout/internal/tenant"
+)
+
+// refreshTokenPrefix starts every refresh token so leaked tokens are easy to recognize
+const refreshTokenPrefix = "rt_"
+
+var (
+	// ErrInvalidCredentials is returned when a login names an unknown user,
+	// a user without password or carries the wrong password
+	ErrInvalidCredentials = errors.New("invalid email or password")
+
+	// ErrInvalidRefreshToken is returned for refresh tokens that are unknown,
+	// expired or revoked
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+)
+
+// TokenPair is the result of a login or refresh
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	// ExpiresIn is the lifetime of the access token
+	ExpiresIn time.Duration
+}
+
+// Sessions logs users in with their password. It issues HS256 access tokens
+// that carry the scopes of the user's role, and single-use refresh tokens
+// that are rotated on every refresh.
+type Sessions struct {
+	users      repository.UserRepository
+	tokens     repository.RefreshTokenRepository
+	secret     []byte
+	issuer     string
+	audience   string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+// NewSessions creates a session service that signs access tokens with the
+// JWT secret of cfg
+func NewSessions(cfg config.AuthConfig, users repository.UserRepository, tokens repository.RefreshTokenRepository) (*Sessions, error) {
+	if cfg.JWTSecret == "" {
+		return nil, fmt.Errorf("auth.jwt_secret is required to issue access tokens")
+	}
+	if cfg.AccessTokenTTL <= 0 || cfg.RefreshTokenTTL <= 0 {
+		return nil, fmt.Errorf("auth.access_token_ttl and auth.refresh_token_ttl must be positive")
+	}
+
+	return &Sessions{
+		users:      users,
+		tokens:     tokens,
+		secret:     []byte(cfg.JWTSecret),
+		issuer:     cfg.Issuer,
+		audience:   cfg.Audience,
+		accessTTL:  cfg.AccessTokenTTL,
+		refreshTTL: cfg.RefreshTokenTTL,
+	}, nil
+}
+
+// Login checks the password of the user with the given email address in the
+// tenant of ctx and starts a new refresh token family
+func (s *Sessions) Login(ctx context.Context, email, password string) (*TokenPair, error) {
+	user, err := s.users.GetByEmail(ctx, email)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("failed to look up user: %w", err)
+	}
+
+	hash := dummyHash()
+	if user != nil && user.PasswordHash != "" {
+		hash = user.PasswordHash
+	}
+	ok, err := CheckPassword(hash, password)
+	if err != nil {
+		return nil, err
+	}
+	if !ok || user == nil || user.PasswordHash == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	return s.issue(ctx, user, uuid.New(), time.Now())
+}
+
+// Refresh exchanges a refresh token for a new token pair and revokes it.
+// Presenting a token that was already rotated means it leaked, so its whole
+// family is revoked.
+func (s *Sessions) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	stored, err := s.lookup(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	ctx = tenant.WithID(ctx, stored.TenantID)
+
+	now := time.Now()
+	if stored.RevokedAt != nil {
+		return nil, s.revokeFamily(ctx, stored.FamilyID, now)
+	}
+	if !now.Before(stored.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// Revoking only succeeds once, so concurrent refreshes cannot both win
+	if err := s.tokens.Revoke(ctx, stored.ID, now); errors.Is(err, repository.ErrNotFound) {
+		return nil, s.revokeFamily(ctx, stored.FamilyID, now)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+
+	user, err := s.users.Get(ctx, stored.UserID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up user: %w", err)
+	}
+
+	return s.issue(ctx, user, stored.FamilyID, now)
+}
+
+// Logout revokes a refresh token together with every token of its family
+func (s *Sessions) Logout(ctx context.Context, refreshToken string) error {
+	stored, err := s.lookup(ctx, refreshToken)
+	if err != nil {
+		return err
+	}
+	ctx = tenant.WithID(ctx, stored.TenantID)
+	if err := s.tokens.RevokeFamily(ctx, stored.FamilyID, time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return nil
+}
+
+// lookup returns the stored refresh token matching a presented one. Tokens
+// are found in every tenant, since the token itself identifies its tenant.
+func (s *Sessions) lookup(ctx context.Context, refreshToken string) (*models.RefreshToken, error) {
+	secret, ok := strings.CutPrefix(refreshToken, refreshTokenPrefix)
+	if !ok || secret == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	stored, err := s.tokens.GetByHash(tenant.AllTenants(ctx), hashSecret(secret))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up refresh token: %w", err)
+	}
+	return stored, nil
+}
+
+// revokeFamily revokes a refresh token family after a token was replayed and
+// returns the error to report to the caller
+func (s *Sessions) revokeFamily(ctx context.Context, familyID uuid.UUID, at time.Time) error {
+	if err := s.tokens.RevokeFamily(ctx, familyID, at); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return ErrInvalidRefreshToken
+}
+
+// issue signs an access token for user and stores a new refresh token in family
+func (s *Sessions) issue(ctx context.Context, user *models.User, family uuid.UUID, now time.Time) (*TokenPair, error) {
+	role, ok := authz.ParseRole(user.Role)
+	if !ok {
+		return nil, fmt.Errorf("user %s has unknown role %q", user.ID, user.Role)
+	}
+
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   user.ID.String(),
+			Issuer:    s.issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.accessTTL)),
+		},
+		Scope:  strings.Join(role.Scopes(), " "),
+		Tenant: user.TenantID,
+	}
+	if s.audience != "" {
+		claims.Audience = jwt.ClaimStrings{s.audience}
+	}
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign access token: %w", err)
+	}
+
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	secret := base64.RawURLEncoding.EncodeToString(secretBytes)
+	if err := s.tokens.Create(ctx, &models.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  family,
+		TokenHash: hashSecret(secret),
+		ExpiresAt: now.Add(s.refreshTTL),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshTokenPrefix + secret,
+		ExpiresIn:    s.accessTTL,
+	}, nil
+}
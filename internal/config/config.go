@@ -2,7 +2,10 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -11,21 +14,138 @@ import (
 type Config struct {
 	Server   ServerConfig   `mapstructure:"server"`
 	Database DatabaseConfig `mapstructure:"database"`
+	Auth     AuthConfig     `mapstructure:"auth"`
+	Log      LogConfig      `mapstructure:"log"`
+	Metrics  MetricsConfig  `mapstructure:"metrics"`
+	Tracing  TracingConfig  `mapstructure:"tracing"`
+	Health   HealthConfig   `mapstructure:"health"`
 }
 
 // ServerConfig holds server-related configuration
 type ServerConfig struct {
 	Port string `mapstructure:"port"`
 	Mode string `mapstructure:"mode"` // debug, release, test
+
+	// CursorSecret signs pagination cursors. When empty a random secret is
+	// generated at startup, so cursors do not survive restarts or work across
+	// replicas.
+	CursorSecret string `mapstructure:"cursor_secret"`
+
+	// PublicURL is the scheme and host clients reach the server at, such as
+	// "https://api.example.com", used as server URL in the served OpenAPI
+	// documents. When empty it is derived from each request and its
+	// X-Forwarded-Proto, X-Forwarded-Host and X-Forwarded-Prefix headers.
+	PublicURL string `mapstructure:"public_url"`
+
+	// ValidateResponses checks every response against the OpenAPI spec. In
+	// debug and test mode mismatches become 500 errors; in release mode they
+	// are only logged and counted.
+	ValidateResponses bool `mapstructure:"validate_responses"`
+
+	// RequestTimeout is the deadline of API requests whose operation does
+	// not set x-timeout in the spec; zero disables it
+	RequestTimeout time.Duration `mapstructure:"request_timeout"`
+
+	// http.Server limits; a zero timeout means no timeout
+	ReadTimeout       time.Duration `mapstructure:"read_timeout"`
+	ReadHeaderTimeout time.Duration `mapstructure:"read_header_timeout"`
+	WriteTimeout      time.Duration `mapstructure:"write_timeout"`
+	IdleTimeout       time.Duration `mapstructure:"idle_timeout"`
+	MaxHeaderBytes    int           `mapstructure:"max_header_bytes"`
+
+	// ShutdownDelay is how long the health check fails before the server
+	// stops accepting connections on SIGINT or SIGTERM, giving load
+	// balancers time to take the instance out of rotation
+	ShutdownDelay time.Duration `mapstructure:"shutdown_delay"`
+	// ShutdownTimeout bounds how long in-flight requests may take to finish
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
 }
 
+// Supported database drivers
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// SQLiteInMemory is the database name that selects an in-memory SQLite database
+const SQLiteInMemory = ":memory:"
+
 // DatabaseConfig holds database-related configuration
 type DatabaseConfig struct {
+	Driver   string `mapstructure:"driver"` // mysql, postgres, sqlite
 	Host     string `mapstructure:"host"`
 	Port     string `mapstructure:"port"`
 	User     string `mapstructure:"user"`
 	Password string `mapstructure:"password"`
-	Name     string `mapstructure:"name"`
+	Name     string `mapstructure:"name"`    // database name, or file path for sqlite
+	SSLMode  string `mapstructure:"sslmode"` // postgres only
+
+	// AutoMigrate syncs the schema from the GORM models on startup instead of
+	// relying on versioned migrations. Development only; rejected in release mode.
+	AutoMigrate bool `mapstructure:"auto_migrate"`
+}
+
+// AuthConfig holds authentication-related configuration. Authentication is
+// enabled when at least one key source is configured.
+type AuthConfig struct {
+	// JWTSecret verifies HS256 bearer tokens
+	JWTSecret string `mapstructure:"jwt_secret"`
+	// JWKSFile is a local JSON Web Key Set with the public keys of RS256 and
+	// ES256 bearer tokens
+	JWKSFile string `mapstructure:"jwks_file"`
+	Issuer   string `mapstructure:"issuer"`   // expected iss claim; not checked when empty
+	Audience string `mapstructure:"audience"` // expected aud claim; not checked when empty
+	// AccessTokenTTL is the lifetime of the access tokens issued by /auth/login
+	AccessTokenTTL time.Duration `mapstructure:"access_token_ttl"`
+	// RefreshTokenTTL is how long a refresh token stays valid without being used
+	RefreshTokenTTL time.Duration `mapstructure:"refresh_token_ttl"`
+	// APIKeys authenticates requests with API keys even when no bearer token
+	// key is configured; bearer tokens are then rejected
+	APIKeys bool `mapstructure:"api_keys"`
+}
+
+// LogConfig holds logging-related configuration
+type LogConfig struct {
+	Level  string `mapstructure:"level"`  // debug, info, warn, error
+	Format string `mapstructure:"format"` // text, json
+}
+
+// MetricsConfig holds Prometheus metrics configuration
+type MetricsConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Path    string `mapstructure:"path"`
+	// Port serves the metrics on a separate admin listener. When empty they
+	// are served on the API port.
+	Port string `mapstructure:"port"`
+}
+
+// TracingConfig holds OpenTelemetry tracing configuration
+type TracingConfig struct {
+	Enabled     bool   `mapstructure:"enabled"`
+	Exporter    string `mapstructure:"exporter"` // stdout, otlp
+	Endpoint    string `mapstructure:"endpoint"` // host:port of the OTLP/HTTP collector
+	Insecure    bool   `mapstructure:"insecure"` // send OTLP over plain HTTP
+	ServiceName string `mapstructure:"service_name"`
+	// SampleRatio is the fraction of new traces that are recorded. Requests
+	// that carry a sampled trace context are always recorded.
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+}
+
+// HealthConfig holds the configuration of the readiness checks
+type HealthConfig struct {
+	// CheckTimeout bounds each dependency check of the readiness probe
+	CheckTimeout time.Duration `mapstructure:"check_timeout"`
+	// DiskPath is the directory whose file system is checked for free space
+	DiskPath string `mapstructure:"disk_path"`
+	// DiskMinFreeMB is the free space below which the service is reported as
+	// degraded; zero disables the disk check
+	DiskMinFreeMB uint64 `mapstructure:"disk_min_free_mb"`
+}
+
+// Enabled reports whether a key source for bearer tokens is configured
+func (c *AuthConfig) Enabled() bool {
+	return c.JWTSecret != "" || c.JWKSFile != ""
 }
 
 // Load reads configuration from file and environment variables
@@ -62,25 +182,137 @@ func Load(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("unable to decode config: %w", err)
 	}
 
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
 	return &cfg, nil
 }
 
+// validate checks option combinations that cannot be expressed by defaults
+func (c *Config) validate() error {
+	if c.Database.AutoMigrate && c.Server.Mode == "release" {
+		return fmt.Errorf("database.auto_migrate is a development option and cannot be enabled in release mode; use \"server migrate up\" instead")
+	}
+	if c.Metrics.Enabled && c.Metrics.Port != "" && c.Metrics.Port == c.Server.Port {
+		return fmt.Errorf("metrics.port must differ from server.port; leave it empty to serve metrics on the API port")
+	}
+	if c.Server.WriteTimeout > 0 && c.Server.RequestTimeout > 0 && c.Server.WriteTimeout <= c.Server.RequestTimeout {
+		return fmt.Errorf("server.write_timeout must be longer than server.request_timeout, or requests that hit their deadline cannot be answered with 504")
+	}
+	if c.Server.PublicURL != "" {
+		if u, err := url.Parse(c.Server.PublicURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("server.public_url must be an absolute http or https URL, got %q", c.Server.PublicURL)
+		}
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		return fmt.Errorf("tracing.sample_ratio must be between 0 and 1")
+	}
+	if !c.Auth.Enabled() && !c.Auth.APIKeys && c.Server.Mode == "release" {
+		return fmt.Errorf("authentication must be configured in release mode; set auth.jwt_secret, auth.jwks_file or auth.api_keys")
+	}
+	return nil
+}
+
 // setDefaults sets default configuration values
 func setDefaults() {
 	// Server defaults
 	viper.SetDefault("server.port", "8080")
 	viper.SetDefault("server.mode", "debug")
+	viper.SetDefault("server.cursor_secret", "")
+	viper.SetDefault("server.public_url", "")
+	viper.SetDefault("server.validate_responses", false)
+	viper.SetDefault("server.request_timeout", "30s")
+	viper.SetDefault("server.read_timeout", "15s")
+	viper.SetDefault("server.read_header_timeout", "5s")
+	viper.SetDefault("server.write_timeout", "60s")
+	viper.SetDefault("server.idle_timeout", "120s")
+	viper.SetDefault("server.max_header_bytes", 1<<20)
+	viper.SetDefault("server.shutdown_delay", "5s")
+	viper.SetDefault("server.shutdown_timeout", "30s")
 
 	// Database defaults
+	viper.SetDefault("database.driver", DriverMySQL)
 	viper.SetDefault("database.host", "localhost")
 	viper.SetDefault("database.port", "3306")
 	viper.SetDefault("database.user", "root")
 	viper.SetDefault("database.password", "password")
 	viper.SetDefault("database.name", "example_db")
+	viper.SetDefault("database.sslmode", "disable")
+	viper.SetDefault("database.auto_migrate", false)
+
+	// Auth defaults
+	viper.SetDefault("auth.jwt_secret", "")
+	viper.SetDefault("auth.jwks_file", "")
+	viper.SetDefault("auth.issuer", "")
+	viper.SetDefault("auth.audience", "")
+	viper.SetDefault("auth.access_token_ttl", "15m")
+	viper.SetDefault("auth.refresh_token_ttl", "720h")
+	viper.SetDefault("auth.api_keys", false)
+
+	// Log defaults
+	viper.SetDefault("log.level", "info")
+	viper.SetDefault("log.format", "text")
+
+	// Metrics defaults
+	viper.SetDefault("metrics.enabled", false)
+	viper.SetDefault("metrics.path", "/metrics")
+	viper.SetDefault("metrics.port", "")
+
+	// Tracing defaults
+	viper.SetDefault("tracing.enabled", false)
+	viper.SetDefault("tracing.exporter", "stdout")
+	viper.SetDefault("tracing.endpoint", "localhost:4318")
+	viper.SetDefault("tracing.insecure", true)
+	viper.SetDefault("tracing.service_name", "oapi-codegen-layout")
+	viper.SetDefault("tracing.sample_ratio", 1.0)
+
+	// Health defaults
+	viper.SetDefault("health.check_timeout", "2s")
+	viper.SetDefault("health.disk_path", ".")
+	viper.SetDefault("health.disk_min_free_mb", 100)
 }
 
-// GetDSN returns the database DSN string
+// GetDSN returns the database DSN string for the configured driver
 func (c *DatabaseConfig) GetDSN() string {
+	switch c.Driver {
+	case DriverPostgres:
+		return c.postgresDSN()
+	case DriverSQLite:
+		return c.sqliteDSN()
+	default:
+		return c.mysqlDSN()
+	}
+}
+
+// IsInMemory reports whether the configuration selects an in-memory SQLite database
+func (c *DatabaseConfig) IsInMemory() bool {
+	return c.Driver == DriverSQLite && c.sqliteDSN() == SQLiteInMemory
+}
+
+// mysqlDSN builds a go-sql-driver/mysql DSN
+func (c *DatabaseConfig) mysqlDSN() string {
 	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
 		c.User, c.Password, c.Host, c.Port, c.Name)
 }
+
+// postgresDSN builds a postgres:// URL; unlike the keyword/value form it
+// escapes values containing spaces, quotes or other special characters
+func (c *DatabaseConfig) postgresDSN() string {
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.User, c.Password),
+		Host:     net.JoinHostPort(c.Host, c.Port),
+		Path:     "/" + c.Name,
+		RawQuery: url.Values{"sslmode": {c.SSLMode}}.Encode(),
+	}
+	return dsn.String()
+}
+
+// sqliteDSN builds a SQLite DSN; the database name is used as the file path
+func (c *DatabaseConfig) sqliteDSN() string {
+	if c.Name == "" || c.Name == SQLiteInMemory {
+		return SQLiteInMemory
+	}
+	return c.Name
+}
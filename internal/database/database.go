@@ -2,30 +2,82 @@ package database
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"oapi-codegen-layout/internal/config"
+	"oapi-codegen-layout/internal/logging"
 	"oapi-codegen-layout/internal/models"
+	"oapi-codegen-layout/internal/tenant"
+	"oapi-codegen-layout/internal/tracing"
 
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-// InitDB initializes the database connection and runs migrations
+// InitDB initializes the database connection. Schema changes are applied with
+// versioned migrations (see package migrate); GORM AutoMigrate only runs when
+// cfg.AutoMigrate is enabled for local development.
 func InitDB(cfg *config.DatabaseConfig) (*gorm.DB, error) {
-	// Create DSN (Data Source Name) from config
-	dsn := cfg.GetDSN()
+	// Select the dialector for the configured driver
+	dialector, err := NewDialector(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	// Open database connection
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(dialector, &gorm.Config{
+		// Query logs carry the request ID of the statement context
+		Logger: logging.NewGormLogger(slog.Default()),
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	// Auto-migrate database schemas
-	if err := db.AutoMigrate(&models.User{}, &models.Product{}); err != nil {
-		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	// Scope every query on a tenant-owned model to the tenant of its context
+	if err := db.Use(tenant.Plugin{}); err != nil {
+		return nil, fmt.Errorf("failed to register tenant scoping: %w", err)
+	}
+
+	// Trace every query as a child of the request span
+	if err := db.Use(tracing.Plugin{}); err != nil {
+		return nil, fmt.Errorf("failed to register query tracing: %w", err)
+	}
+
+	// Every connection to an in-memory SQLite database gets its own empty
+	// database, so the pool must be pinned to a single connection
+	if cfg.IsInMemory() {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, fmt.Errorf("failed to access database pool: %w", err)
+		}
+		sqlDB.SetMaxOpenConns(1)
 	}
 
-	log.Println("Database connected and migrated successfully")
+	// Auto-migrate database schemas (development only)
+	if cfg.AutoMigrate {
+		slog.Warn("Running GORM AutoMigrate; use versioned migrations outside development")
+		if err := db.AutoMigrate(&models.User{}, &models.Product{}, &models.APIKey{}, &models.RefreshToken{}); err != nil {
+			return nil, fmt.Errorf("failed to migrate database: %w", err)
+		}
+	}
+
+	slog.Info("Database connected", slog.String("driver", cfg.Driver))
 	return db, nil
 }
+
+// NewDialector returns the GORM dialector for the configured driver
+func NewDialector(cfg *config.DatabaseConfig) (gorm.Dialector, error) {
+	dsn := cfg.GetDSN()
+
+	switch cfg.Driver {
+	case config.DriverMySQL:
+		return mysql.Open(dsn), nil
+	case config.DriverPostgres:
+		return postgres.Open(dsn), nil
+	case config.DriverSQLite:
+		return sqlite.Open(dsn), nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %q", cfg.Driver)
+	}
+}
@@ -0,0 +1,95 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	sqlite "github.com/glebarez/go-sqlite"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Driver error codes reported for unique constraint violations
+const (
+	mysqlDuplicateEntry        = 1062
+	postgresUniqueViolation    = "23505"
+	sqliteConstraintUnique     = 2067
+	sqliteConstraintPrimaryKey = 1555
+)
+
+// UniqueViolation describes a write rejected by a unique constraint
+type UniqueViolation struct {
+	// Constraint names the violated constraint as reported by the driver:
+	// the index name on MySQL and PostgreSQL (e.g. "idx_users_tenant_email")
+	// and the last table-qualified column on SQLite (e.g. "users.email")
+	Constraint string
+	Err        error
+}
+
+func (e *UniqueViolation) Error() string {
+	return fmt.Sprintf("unique constraint %q violated: %v", e.Constraint, e.Err)
+}
+
+func (e *UniqueViolation) Unwrap() error {
+	return e.Err
+}
+
+// TranslateError converts driver-specific unique constraint errors from
+// MySQL, PostgreSQL and SQLite into a *UniqueViolation. Other errors are
+// returned unchanged.
+func TranslateError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+		// Message format: Duplicate entry 'value' for key 'table.index'
+		return &UniqueViolation{Constraint: mysqlConstraint(mysqlErr.Message), Err: err}
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == postgresUniqueViolation {
+		return &UniqueViolation{Constraint: pgErr.ConstraintName, Err: err}
+	}
+
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) && (sqliteErr.Code() == sqliteConstraintUnique || sqliteErr.Code() == sqliteConstraintPrimaryKey) {
+		// Message format: UNIQUE constraint failed: table.column (2067)
+		return &UniqueViolation{Constraint: sqliteConstraint(sqliteErr.Error()), Err: err}
+	}
+
+	return err
+}
+
+// mysqlConstraint extracts the index name from a MySQL duplicate entry message
+func mysqlConstraint(message string) string {
+	_, key, ok := strings.Cut(message, " for key '")
+	if !ok {
+		return ""
+	}
+	key = strings.TrimSuffix(key, "'")
+
+	// MySQL 8 prefixes the index with its table name
+	if _, index, ok := strings.Cut(key, "."); ok {
+		return index
+	}
+	return key
+}
+
+// sqliteConstraint extracts the last table-qualified column from a SQLite
+// constraint message. Composite indexes list their scoping columns, such as
+// tenant_id, first, so the last column is the one that is taken.
+func sqliteConstraint(message string) string {
+	const marker = "constraint failed: "
+	i := strings.LastIndex(message, marker)
+	if i < 0 {
+		return ""
+	}
+	columns, _, _ := strings.Cut(message[i+len(marker):], " (")
+	if j := strings.LastIndex(columns, ","); j >= 0 {
+		columns = columns[j+1:]
+	}
+	return strings.TrimSpace(columns)
+}
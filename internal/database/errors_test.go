@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"oapi-codegen-layout/internal/config"
+	"oapi-codegen-layout/internal/models"
+	"oapi-codegen-layout/internal/tenant"
+)
+
+func TestTranslateErrorDrivers(t *testing.T) {
+	tests := map[string]struct {
+		err        error
+		constraint string
+	}{
+		"mysql": {
+			err:        &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'a@example.com' for key 'users.idx_users_email'"},
+			constraint: "idx_users_email",
+		},
+		"mysql 5.7": {
+			err:        &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'a@example.com' for key 'idx_users_email'"},
+			constraint: "idx_users_email",
+		},
+		"postgres": {
+			err:        &pgconn.PgError{Code: "23505", ConstraintName: "idx_users_email"},
+			constraint: "idx_users_email",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var violation *UniqueViolation
+			if !errors.As(TranslateError(fmt.Errorf("wrapped: %w", tt.err)), &violation) {
+				t.Fatalf("expected a UniqueViolation")
+			}
+			if violation.Constraint != tt.constraint {
+				t.Fatalf("expected constraint %q, got %q", tt.constraint, violation.Constraint)
+			}
+		})
+	}
+}
+
+func TestTranslateErrorSQLite(t *testing.T) {
+	db, err := InitDB(&config.DatabaseConfig{Driver: config.DriverSQLite, Name: config.SQLiteInMemory, AutoMigrate: true})
+	if err != nil {
+		t.Fatalf("InitDB: %v", err)
+	}
+
+	db = db.WithContext(tenant.WithID(context.Background(), tenant.Default))
+
+	if err := db.Create(&models.User{Email: "a@example.com", Name: "A"}).Error; err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	err = TranslateError(db.Create(&models.User{Email: "a@example.com", Name: "B"}).Error)
+
+	var violation *UniqueViolation
+	if !errors.As(err, &violation) {
+		t.Fatalf("expected a UniqueViolation, got %v", err)
+	}
+	if violation.Constraint != "users.email" {
+		t.Fatalf("expected constraint users.email, got %q", violation.Constraint)
+	}
+}
+
+func TestTranslateErrorPassesThroughOtherErrors(t *testing.T) {
+	other := &mysql.MySQLError{Number: 1045, Message: "Access denied"}
+	if err := TranslateError(other); err != other {
+		t.Fatalf("expected the original error, got %v", err)
+	}
+}
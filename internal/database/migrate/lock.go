@@ -0,0 +1,110 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"oapi-codegen-layout/internal/config"
+)
+
+// lockName identifies the migration lock on MySQL
+const lockName = "schema_migrations"
+
+// lockKey identifies the migration advisory lock on PostgreSQL
+const lockKey int64 = 7242081635
+
+// lockTimeoutSeconds bounds how long MySQL waits for another instance to finish migrating
+const lockTimeoutSeconds = 300
+
+// acquireLock takes a database-wide lock so that only one instance runs
+// migrations at a time. The lock is bound to conn and must be released on it.
+func acquireLock(ctx context.Context, conn *sql.Conn, driver string) error {
+	switch driver {
+	case config.DriverMySQL:
+		var acquired sql.NullInt64
+		if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, lockTimeoutSeconds).Scan(&acquired); err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		if !acquired.Valid || acquired.Int64 != 1 {
+			return fmt.Errorf("timed out waiting for migration lock")
+		}
+	case config.DriverPostgres:
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+	case config.DriverSQLite:
+		// SQLite has no named locks. Each migration instead takes the write
+		// lock of the database file when it begins, see beginMigration, and
+		// waits this long for another instance to release it.
+		if _, err := conn.ExecContext(ctx, fmt.Sprintf("PRAGMA busy_timeout = %d", lockTimeoutSeconds*1000)); err != nil {
+			return fmt.Errorf("failed to set migration lock timeout: %w", err)
+		}
+	}
+	return nil
+}
+
+// releaseLock releases the lock taken by acquireLock
+func releaseLock(ctx context.Context, conn *sql.Conn, driver string) error {
+	var err error
+	switch driver {
+	case config.DriverMySQL:
+		_, err = conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", lockName)
+	case config.DriverPostgres:
+		_, err = conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", lockKey)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to release migration lock: %w", err)
+	}
+	return nil
+}
+
+// migrationTx is the transaction a single migration runs in
+type migrationTx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	Commit() error
+	Rollback() error
+}
+
+// beginMigration starts the transaction of a migration. On SQLite it takes
+// the write lock up front with BEGIN IMMEDIATE, so that concurrent migrators
+// apply migrations one at a time and see each other's changes.
+func beginMigration(ctx context.Context, conn *sql.Conn, driver string) (migrationTx, error) {
+	if driver != config.DriverSQLite {
+		return conn.BeginTx(ctx, nil)
+	}
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return nil, err
+	}
+	return &immediateTx{Conn: conn}, nil
+}
+
+// immediateTx is a SQLite transaction started with BEGIN IMMEDIATE, which
+// database/sql cannot start itself
+type immediateTx struct {
+	*sql.Conn
+	done bool
+}
+
+// Commit commits the transaction
+func (tx *immediateTx) Commit() error {
+	if tx.done {
+		return sql.ErrTxDone
+	}
+	if _, err := tx.ExecContext(context.Background(), "COMMIT"); err != nil {
+		return err
+	}
+	tx.done = true
+	return nil
+}
+
+// Rollback aborts the transaction unless it was already committed
+func (tx *immediateTx) Rollback() error {
+	if tx.done {
+		return sql.ErrTxDone
+	}
+	tx.done = true
+	_, err := tx.ExecContext(context.Background(), "ROLLBACK")
+	return err
+}
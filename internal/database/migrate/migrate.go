@@ -0,0 +1,272 @@
+// Package migrate applies the versioned SQL migrations embedded in the binary
+// and records them in the schema_migrations table.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"oapi-codegen-layout/internal/config"
+)
+
+// tableName is the table that records applied migrations
+const tableName = "schema_migrations"
+
+// Status describes a known migration and whether it has been applied
+type Status struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+}
+
+// Migrator applies and rolls back migrations for a single database
+type Migrator struct {
+	db         *sql.DB
+	driver     string
+	migrations []Migration
+}
+
+// New creates a migrator using the embedded migrations for the given driver
+func New(db *sql.DB, driver string) (*Migrator, error) {
+	migrations, err := Load(driver)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		db:         db,
+		driver:     driver,
+		migrations: migrations,
+	}, nil
+}
+
+// Up applies all pending migrations in version order and returns how many were applied
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+			ok, err := m.apply(ctx, conn, migration, true)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			slog.InfoContext(ctx, "Applied migration", slog.String("migration", fmt.Sprintf("%04d_%s", migration.Version, migration.Name)))
+			applied++
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down rolls back the most recently applied migrations, at most steps of them,
+// and returns how many were rolled back
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	rolledBack := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && rolledBack < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+			ok, err := m.apply(ctx, conn, migration, false)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			slog.InfoContext(ctx, "Rolled back migration", slog.String("migration", fmt.Sprintf("%04d_%s", migration.Version, migration.Name)))
+			rolledBack++
+		}
+		return nil
+	})
+	return rolledBack, err
+}
+
+// Status returns every known migration with the time it was applied, if any
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	if err := m.ensureTable(ctx, conn); err != nil {
+		return nil, err
+	}
+
+	done, err := m.appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, len(m.migrations))
+	for i, migration := range m.migrations {
+		statuses[i] = Status{Version: migration.Version, Name: migration.Name}
+		if appliedAt, ok := done[migration.Version]; ok {
+			statuses[i].AppliedAt = &appliedAt
+		}
+	}
+	return statuses, nil
+}
+
+// Pending returns how many known migrations have not been applied. Unlike
+// Status it does not create the schema_migrations table, so it fails on a
+// database that was never migrated.
+func (m *Migrator) Pending(ctx context.Context) (int, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	done, err := m.appliedVersions(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for _, migration := range m.migrations {
+		if _, ok := done[migration.Version]; !ok {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// withLock runs fn on a dedicated connection while holding the migration lock
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	if err := acquireLock(ctx, conn, m.driver); err != nil {
+		return err
+	}
+	defer func() {
+		// Release with a fresh context so a cancelled ctx does not leak the lock
+		if releaseErr := releaseLock(context.Background(), conn, m.driver); releaseErr != nil && err == nil {
+			err = releaseErr
+		}
+	}()
+
+	if err := m.ensureTable(ctx, conn); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+// ensureTable creates the schema_migrations table if it does not exist
+func (m *Migrator) ensureTable(ctx context.Context, conn *sql.Conn) error {
+	query := "CREATE TABLE IF NOT EXISTS " + tableName + ` (
+		version BIGINT NOT NULL PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMP NOT NULL
+	)`
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create %s table: %w", tableName, err)
+	}
+	return nil
+}
+
+// appliedVersions returns the applied migration versions and when they were applied
+func (m *Migrator) appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM "+tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", tableName, err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", tableName, err)
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// apply runs the up or down script of a migration and records the result in a
+// single transaction, and reports whether it did. A migration that another
+// instance applied or rolled back in the meantime is skipped. MySQL commits
+// DDL implicitly, so a failing MySQL migration may leave partial changes
+// behind.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration, up bool) (bool, error) {
+	script := migration.Down
+	if up {
+		script = migration.Up
+	}
+
+	tx, err := beginMigration(ctx, conn, m.driver)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin migration %04d_%s: %w", migration.Version, migration.Name, err)
+	}
+	defer tx.Rollback()
+
+	var recorded int
+	err = tx.QueryRowContext(ctx,
+		fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE version = %s", tableName, m.placeholder(1)),
+		migration.Version).Scan(&recorded)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", tableName, err)
+	}
+	if (recorded > 0) == up {
+		return false, nil
+	}
+
+	for _, statement := range splitStatements(script) {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return false, fmt.Errorf("migration %04d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx,
+			fmt.Sprintf("INSERT INTO %s (version, name, applied_at) VALUES (%s, %s, %s)",
+				tableName, m.placeholder(1), m.placeholder(2), m.placeholder(3)),
+			migration.Version, migration.Name, time.Now().UTC())
+	} else {
+		_, err = tx.ExecContext(ctx,
+			fmt.Sprintf("DELETE FROM %s WHERE version = %s", tableName, m.placeholder(1)),
+			migration.Version)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to record migration %04d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit migration %04d_%s: %w", migration.Version, migration.Name, err)
+	}
+	return true, nil
+}
+
+// placeholder returns the n-th bind parameter in the driver's syntax
+func (m *Migrator) placeholder(n int) string {
+	if m.driver == config.DriverPostgres {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
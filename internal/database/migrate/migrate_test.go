@@ -0,0 +1,149 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"oapi-codegen-layout/internal/config"
+	"oapi-codegen-layout/internal/database"
+)
+
+func TestLoadEmbeddedMigrations(t *testing.T) {
+	var versions []int64
+	for _, driver := range []string{config.DriverMySQL, config.DriverPostgres, config.DriverSQLite} {
+		migrations, err := Load(driver)
+		if err != nil {
+			t.Fatalf("Load(%s): %v", driver, err)
+		}
+		if versions == nil {
+			for _, m := range migrations {
+				versions = append(versions, m.Version)
+			}
+			continue
+		}
+		// Every driver must ship the same set of versions
+		if len(migrations) != len(versions) {
+			t.Fatalf("driver %s has %d migrations, expected %d", driver, len(migrations), len(versions))
+		}
+		for i, m := range migrations {
+			if m.Version != versions[i] {
+				t.Fatalf("driver %s migration %d has version %d, expected %d", driver, i, m.Version, versions[i])
+			}
+		}
+	}
+}
+
+func TestLoadRejectsMissingDown(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0001_init.up.sql": {Data: []byte("CREATE TABLE t (id INT);")},
+	}
+	if _, err := loadFS(fsys); err == nil {
+		t.Fatal("expected an error for a migration without a down file")
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	script := "-- comment\nCREATE TABLE a (\n  id INT\n);\n\nCREATE INDEX i ON a (id);\n"
+	statements := splitStatements(script)
+	if len(statements) != 2 {
+		t.Fatalf("expected 2 statements, got %d: %q", len(statements), statements)
+	}
+}
+
+func TestUpDownSQLite(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.InitDB(&config.DatabaseConfig{Driver: config.DriverSQLite, Name: config.SQLiteInMemory})
+	if err != nil {
+		t.Fatalf("InitDB: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("DB: %v", err)
+	}
+	defer sqlDB.Close()
+
+	migrator, err := New(sqlDB, config.DriverSQLite)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if applied != len(migrator.migrations) {
+		t.Fatalf("expected %d migrations applied, got %d", len(migrator.migrations), applied)
+	}
+
+	// A second run is a no-op
+	if applied, err := migrator.Up(ctx); err != nil || applied != 0 {
+		t.Fatalf("expected no pending migrations, got %d (%v)", applied, err)
+	}
+
+	if _, err := migrator.Down(ctx, 1); err != nil {
+		t.Fatalf("Down: %v", err)
+	}
+	if pending, err := migrator.Pending(ctx); err != nil || pending != 1 {
+		t.Fatalf("expected 1 pending migration after down, got %d (%v)", pending, err)
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	last := statuses[len(statuses)-1]
+	if last.AppliedAt != nil {
+		t.Fatalf("expected migration %d to be pending after down", last.Version)
+	}
+	for _, status := range statuses[:len(statuses)-1] {
+		if status.AppliedAt == nil {
+			t.Fatalf("expected migration %d to remain applied", status.Version)
+		}
+	}
+}
+
+func TestApplySkipsRecordedMigrations(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.DatabaseConfig{Driver: config.DriverSQLite, Name: filepath.Join(t.TempDir(), "app.db")}
+
+	// Two instances share the database file; the second read the applied
+	// versions before the first migrated
+	migrators := make([]*Migrator, 2)
+	for i := range migrators {
+		db, err := database.InitDB(cfg)
+		if err != nil {
+			t.Fatalf("InitDB: %v", err)
+		}
+		sqlDB, err := db.DB()
+		if err != nil {
+			t.Fatalf("DB: %v", err)
+		}
+		t.Cleanup(func() { sqlDB.Close() })
+
+		if migrators[i], err = New(sqlDB, config.DriverSQLite); err != nil {
+			t.Fatalf("New: %v", err)
+		}
+	}
+	if _, err := migrators[0].Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	err := migrators[1].withLock(ctx, func(conn *sql.Conn) error {
+		for _, migration := range migrators[1].migrations {
+			applied, err := migrators[1].apply(ctx, conn, migration, true)
+			if err != nil {
+				return err
+			}
+			if applied {
+				t.Errorf("expected migration %d to be skipped", migration.Version)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+}
@@ -0,0 +1,14 @@
+CREATE TABLE api_keys (
+    id CHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    secret_hash CHAR(64) NOT NULL,
+    scopes VARCHAR(1000) NOT NULL,
+    expires_at DATETIME(3) NULL,
+    last_used_at DATETIME(3) NULL,
+    revoked_at DATETIME(3) NULL,
+    created_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    UNIQUE INDEX idx_api_keys_prefix (prefix)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,15 @@
+ALTER TABLE users ADD COLUMN password_hash VARCHAR(255) NULL;
+
+CREATE TABLE refresh_tokens (
+    id CHAR(36) NOT NULL,
+    user_id CHAR(36) NOT NULL,
+    family_id CHAR(36) NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    expires_at DATETIME(3) NOT NULL,
+    revoked_at DATETIME(3) NULL,
+    created_at DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    UNIQUE INDEX idx_refresh_tokens_token_hash (token_hash),
+    INDEX idx_refresh_tokens_family_id (family_id),
+    CONSTRAINT fk_refresh_tokens_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
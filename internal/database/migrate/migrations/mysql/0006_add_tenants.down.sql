@@ -0,0 +1,16 @@
+ALTER TABLE refresh_tokens
+    DROP INDEX idx_refresh_tokens_tenant_id,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE api_keys
+    DROP INDEX idx_api_keys_tenant_id,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE products
+    DROP INDEX idx_products_tenant_id,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE users
+    DROP INDEX idx_users_tenant_email,
+    ADD UNIQUE INDEX idx_users_email (email),
+    DROP COLUMN tenant_id;
@@ -0,0 +1,16 @@
+ALTER TABLE users
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default',
+    DROP INDEX idx_users_email,
+    ADD UNIQUE INDEX idx_users_tenant_email (tenant_id, email);
+
+ALTER TABLE products
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default',
+    ADD INDEX idx_products_tenant_id (tenant_id);
+
+ALTER TABLE api_keys
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default',
+    ADD INDEX idx_api_keys_tenant_id (tenant_id);
+
+ALTER TABLE refresh_tokens
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default',
+    ADD INDEX idx_refresh_tokens_tenant_id (tenant_id);
@@ -0,0 +1,13 @@
+CREATE TABLE api_keys (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    secret_hash CHAR(64) NOT NULL,
+    scopes VARCHAR(1000) NOT NULL,
+    expires_at TIMESTAMPTZ NULL,
+    last_used_at TIMESTAMPTZ NULL,
+    revoked_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NULL,
+    updated_at TIMESTAMPTZ NULL
+);
+CREATE UNIQUE INDEX idx_api_keys_prefix ON api_keys (prefix);
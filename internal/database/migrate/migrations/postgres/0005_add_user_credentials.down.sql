@@ -0,0 +1,3 @@
+DROP TABLE refresh_tokens;
+
+ALTER TABLE users DROP COLUMN password_hash;
@@ -0,0 +1,13 @@
+CREATE TABLE products (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    description VARCHAR(1000) NULL,
+    price DECIMAL(10,2) NOT NULL,
+    category VARCHAR(100) NOT NULL,
+    stock INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NULL,
+    updated_at DATETIME NULL,
+    deleted_at DATETIME NULL
+);
+CREATE INDEX idx_products_category ON products (category);
+CREATE INDEX idx_products_deleted_at ON products (deleted_at);
@@ -0,0 +1,13 @@
+ALTER TABLE users ADD COLUMN password_hash VARCHAR(255) NULL;
+
+CREATE TABLE refresh_tokens (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    user_id CHAR(36) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    family_id CHAR(36) NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    expires_at DATETIME NOT NULL,
+    revoked_at DATETIME NULL,
+    created_at DATETIME NULL
+);
+CREATE UNIQUE INDEX idx_refresh_tokens_token_hash ON refresh_tokens (token_hash);
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens (family_id);
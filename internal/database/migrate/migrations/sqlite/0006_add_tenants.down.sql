@@ -0,0 +1,12 @@
+DROP INDEX idx_refresh_tokens_tenant_id;
+ALTER TABLE refresh_tokens DROP COLUMN tenant_id;
+
+DROP INDEX idx_api_keys_tenant_id;
+ALTER TABLE api_keys DROP COLUMN tenant_id;
+
+DROP INDEX idx_products_tenant_id;
+ALTER TABLE products DROP COLUMN tenant_id;
+
+DROP INDEX idx_users_tenant_email;
+CREATE UNIQUE INDEX idx_users_email ON users (email);
+ALTER TABLE users DROP COLUMN tenant_id;
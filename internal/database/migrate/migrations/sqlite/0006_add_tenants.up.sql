@@ -0,0 +1,12 @@
+ALTER TABLE users ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+DROP INDEX idx_users_email;
+CREATE UNIQUE INDEX idx_users_tenant_email ON users (tenant_id, email);
+
+ALTER TABLE products ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+CREATE INDEX idx_products_tenant_id ON products (tenant_id);
+
+ALTER TABLE api_keys ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+CREATE INDEX idx_api_keys_tenant_id ON api_keys (tenant_id);
+
+ALTER TABLE refresh_tokens ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+CREATE INDEX idx_refresh_tokens_tenant_id ON refresh_tokens (tenant_id);
@@ -0,0 +1,173 @@
+package migrate
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// migrationsFS holds the SQL migration files, one directory per driver
+//
+//go:embed migrations
+var migrationsFS embed.FS
+
+// fileNamePattern matches migration file names such as 0001_create_users.up.sql
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// namePattern matches valid names for new migrations
+var namePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Migration is a single versioned schema change with its up and down SQL
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Load returns the embedded migrations for the given driver, ordered by version
+func Load(driver string) ([]Migration, error) {
+	sub, err := fs.Sub(migrationsFS, "migrations/"+driver)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for driver %q: %w", driver, err)
+	}
+	return loadFS(sub)
+}
+
+// loadFS parses up/down migration pairs from the root of fsys
+func loadFS(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", entry.Name(), err)
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d used by both %q and %q", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s must have both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Create writes an empty up/down migration pair for every driver directory
+// under dir, using the next free version number, and returns the created paths
+func Create(dir, name string) ([]string, error) {
+	name = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), " ", "_"))
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid migration name %q: use letters, digits and underscores", name)
+	}
+
+	drivers, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	// Keep versions aligned across drivers by taking the highest one in use
+	var latest int64
+	for _, driver := range drivers {
+		if !driver.IsDir() {
+			continue
+		}
+		migrations, err := loadFS(os.DirFS(filepath.Join(dir, driver.Name())))
+		if err != nil {
+			return nil, err
+		}
+		if n := len(migrations); n > 0 && migrations[n-1].Version > latest {
+			latest = migrations[n-1].Version
+		}
+	}
+
+	var created []string
+	for _, driver := range drivers {
+		if !driver.IsDir() {
+			continue
+		}
+		for _, direction := range []string{"up", "down"} {
+			path := filepath.Join(dir, driver.Name(), fmt.Sprintf("%04d_%s.%s.sql", latest+1, name, direction))
+			content := fmt.Sprintf("-- %s migration for %s (%s)\n", direction, name, driver.Name())
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				return nil, fmt.Errorf("failed to write %s: %w", path, err)
+			}
+			created = append(created, path)
+		}
+	}
+
+	return created, nil
+}
+
+// splitStatements splits a migration script into individual statements.
+// Statements are terminated by a semicolon at the end of a line; lines
+// starting with "--" are treated as comments.
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+
+		current.WriteString(line)
+		current.WriteString("\n")
+
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSpace(current.String()))
+			current.Reset()
+		}
+	}
+
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+
+	return statements
+}
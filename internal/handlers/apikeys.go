@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"oapi-codegen-layout/internal/apierror"
+	"oapi-codegen-layout/internal/auth"
+	"oapi-codegen-layout/internal/models"
+	"oapi-codegen-layout/internal/pagination"
+	"oapi-codegen-layout/internal/repository"
+	"oapi-codegen-layout/pkg/api/apikeys"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+// maxScopesLength is the length of the scopes column of models.APIKey, which
+// stores the scopes joined by spaces
+const maxScopesLength = 1000
+
+// APIKeyHandler implements the apikeys.ServerInterface generated by oapi-codegen
+type APIKeyHandler struct {
+	repo    repository.APIKeyRepository
+	cursors *pagination.Codec
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(repo repository.APIKeyRepository, cursors *pagination.Codec) *APIKeyHandler {
+	return &APIKeyHandler{
+		repo:    repo,
+		cursors: cursors,
+	}
+}
+
+// Ensure APIKeyHandler implements apikeys.ServerInterface
+var _ apikeys.ServerInterface = (*APIKeyHandler)(nil)
+
+// ListApiKeys returns a list of API keys
+// (GET /api-keys)
+func (h *APIKeyHandler) ListApiKeys(c *gin.Context, params apikeys.ListApiKeysParams) {
+	var filter repository.APIKeyFilter
+
+	// Resolve page size and cursor
+	limit, after, ok := parsePage(c, h.cursors, params.Limit, params.Cursor)
+	if !ok {
+		return
+	}
+	filter.After = after
+
+	// Fetch one extra key to learn whether another page follows
+	filter.Limit = limit + 1
+
+	dbKeys, err := h.repo.List(c.Request.Context(), filter)
+	if err != nil {
+		respondRepositoryError(c, err, "API key", "Failed to retrieve API keys")
+		return
+	}
+
+	if len(dbKeys) > limit {
+		dbKeys = dbKeys[:limit]
+		last := dbKeys[limit-1]
+		setNextPage(c, h.cursors, pagination.Cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	apiKeys := make([]apimodels.APIKey, len(dbKeys))
+	for i, dbKey := range dbKeys {
+		apiKeys[i] = dbAPIKeyToAPIKey(&dbKey)
+	}
+
+	c.JSON(http.StatusOK, apiKeys)
+}
+
+// CreateApiKey creates a new API key and returns its secret once
+// (POST /api-keys)
+func (h *APIKeyHandler) CreateApiKey(c *gin.Context) {
+	var req apimodels.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, apimodels.Error{
+			Code:    "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		field := "expiresAt"
+		apierror.Respond(c, http.StatusBadRequest, apimodels.Error{
+			Code:    "invalid_request",
+			Message: "expiresAt must be in the future",
+			Field:   &field,
+		})
+		return
+	}
+
+	scopes := strings.Join(req.Scopes, " ")
+	if len(scopes) > maxScopesLength {
+		field := "scopes"
+		apierror.Respond(c, http.StatusBadRequest, apimodels.Error{
+			Code:    "invalid_request",
+			Message: fmt.Sprintf("scopes must not exceed %d characters when joined by spaces", maxScopesLength),
+			Field:   &field,
+		})
+		return
+	}
+
+	key, prefix, secretHash, err := auth.GenerateAPIKey()
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, apimodels.Error{
+			Code:    "internal_error",
+			Message: "Failed to generate API key",
+		})
+		return
+	}
+
+	dbKey := &models.APIKey{
+		Name:       req.Name,
+		Prefix:     prefix,
+		SecretHash: secretHash,
+		Scopes:     scopes,
+		ExpiresAt:  req.ExpiresAt,
+	}
+	if err := h.repo.Create(c.Request.Context(), dbKey); err != nil {
+		respondRepositoryError(c, err, "API key", "Failed to create API key")
+		return
+	}
+
+	apiKey := dbAPIKeyToAPIKey(dbKey)
+	c.JSON(http.StatusCreated, apimodels.CreatedAPIKey{
+		Id:        apiKey.Id,
+		Name:      apiKey.Name,
+		Prefix:    apiKey.Prefix,
+		Scopes:    apiKey.Scopes,
+		ExpiresAt: apiKey.ExpiresAt,
+		CreatedAt: apiKey.CreatedAt,
+		Key:       key,
+	})
+}
+
+// RevokeApiKey revokes an API key
+// (DELETE /api-keys/{apiKeyId})
+func (h *APIKeyHandler) RevokeApiKey(c *gin.Context, apiKeyId openapi_types.UUID) {
+	if err := h.repo.Revoke(c.Request.Context(), uuid.UUID(apiKeyId), time.Now()); err != nil {
+		respondRepositoryError(c, err, "API key", "Failed to revoke API key")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// dbAPIKeyToAPIKey converts a stored API key to its API representation, without the secret
+func dbAPIKeyToAPIKey(dbKey *models.APIKey) apimodels.APIKey {
+	return apimodels.APIKey{
+		Id:         openapi_types.UUID(dbKey.ID),
+		Name:       dbKey.Name,
+		Prefix:     dbKey.Prefix,
+		Scopes:     strings.Fields(dbKey.Scopes),
+		ExpiresAt:  dbKey.ExpiresAt,
+		LastUsedAt: dbKey.LastUsedAt,
+		RevokedAt:  dbKey.RevokedAt,
+		CreatedAt:  dbKey.CreatedAt,
+	}
+}
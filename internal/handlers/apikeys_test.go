@@ -0,0 +1,99 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"oapi-codegen-layout/internal/testutil"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+func TestAPIKeyLifecycle(t *testing.T) {
+	for name, opts := range map[string][]testutil.Option{
+		"memory": nil,
+		"sqlite": {testutil.WithSQLite()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := testutil.NewServer(t, opts...)
+
+			created := s.CreateAPIKey(t, apimodels.CreateAPIKeyRequest{Name: "billing", Scopes: []string{"products:read"}})
+			if !strings.HasPrefix(created.Key, "ak_"+created.Prefix+"_") {
+				t.Fatalf("unexpected key format %q for prefix %q", created.Key, created.Prefix)
+			}
+
+			// The key authenticates machine clients with its own scopes
+			admin := s.Token
+			s.Token = ""
+			req := s.NewRequest(t, http.MethodGet, "/products", nil)
+			req.Header.Set("X-API-Key", created.Key)
+			testutil.Expect[[]apimodels.Product](t, s.Serve(req), http.StatusOK)
+
+			req = s.NewRequest(t, http.MethodGet, "/users", nil)
+			req.Header.Set("X-API-Key", created.Key)
+			testutil.ExpectError(t, s.Serve(req), http.StatusForbidden, "insufficient_scope")
+
+			req = s.NewRequest(t, http.MethodGet, "/products", nil)
+			req.Header.Set("X-API-Key", created.Key+"x")
+			testutil.ExpectError(t, s.Serve(req), http.StatusUnauthorized, "unauthorized")
+
+			// Listing never exposes the secret but shows when the key was used
+			s.Token = admin
+			list := testutil.Expect[[]apimodels.APIKey](t, s.Do(t, http.MethodGet, "/api-keys", nil), http.StatusOK)
+			if len(list) != 1 || list[0].Id != created.Id || list[0].LastUsedAt == nil {
+				t.Fatalf("unexpected key list %+v", list)
+			}
+
+			resp := s.Do(t, http.MethodDelete, "/api-keys/"+created.Id.String(), nil)
+			if resp.Code != http.StatusNoContent {
+				t.Fatalf("expected status 204, got %d: %s", resp.Code, resp.Body)
+			}
+			testutil.ExpectError(t, s.Do(t, http.MethodDelete, "/api-keys/"+created.Id.String(), nil), http.StatusNotFound, "not_found")
+
+			s.Token = ""
+			req = s.NewRequest(t, http.MethodGet, "/products", nil)
+			req.Header.Set("X-API-Key", created.Key)
+			testutil.ExpectError(t, s.Serve(req), http.StatusUnauthorized, "unauthorized")
+		})
+	}
+}
+
+func TestAPIKeyExpiry(t *testing.T) {
+	s := testutil.NewServer(t)
+
+	past := time.Now().Add(-time.Hour)
+	testutil.ExpectError(t, s.Do(t, http.MethodPost, "/api-keys", apimodels.CreateAPIKeyRequest{
+		Name: "expired", Scopes: []string{"products:read"}, ExpiresAt: &past,
+	}), http.StatusBadRequest, "invalid_request")
+
+	testutil.ExpectError(t, s.Do(t, http.MethodPost, "/api-keys", apimodels.CreateAPIKeyRequest{
+		Name: "no scopes", Scopes: []string{},
+	}), http.StatusBadRequest, "validation_failed")
+}
+
+func TestAPIKeyScopesLength(t *testing.T) {
+	s := testutil.NewServer(t, testutil.WithSQLite())
+
+	// Scopes that do not fit the stored column are a client error
+	scopes := make([]string, 20)
+	for i := range scopes {
+		scopes[i] = fmt.Sprintf("scope%02d:%s", i, strings.Repeat("x", 50))
+	}
+	apiErr := testutil.ExpectError(t, s.Do(t, http.MethodPost, "/api-keys", apimodels.CreateAPIKeyRequest{
+		Name: "wide", Scopes: scopes,
+	}), http.StatusBadRequest, "invalid_request")
+	if apiErr.Field == nil || *apiErr.Field != "scopes" {
+		t.Fatalf("expected the scopes field to be reported, got %v", apiErr.Field)
+	}
+
+	s.CreateAPIKey(t, apimodels.CreateAPIKeyRequest{Name: "narrow", Scopes: scopes[:15]})
+}
+
+func TestAPIKeyManagementRequiresAdminScope(t *testing.T) {
+	s := testutil.NewServer(t)
+	s.Token = testutil.NewToken(t, testutil.JWTSecret, s.Admin.ID.String(), "users:read", "users:write")
+
+	testutil.ExpectError(t, s.Do(t, http.MethodGet, "/api-keys", nil), http.StatusForbidden, "insufficient_scope")
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"oapi-codegen-layout/internal/apierror"
+	"oapi-codegen-layout/internal/auth"
+	authapi "oapi-codegen-layout/pkg/api/auth"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+// AuthHandler implements the auth.ServerInterface generated by oapi-codegen
+type AuthHandler struct {
+	sessions *auth.Sessions
+}
+
+// NewAuthHandler creates a new auth handler
+func NewAuthHandler(sessions *auth.Sessions) *AuthHandler {
+	return &AuthHandler{
+		sessions: sessions,
+	}
+}
+
+// Ensure AuthHandler implements authapi.ServerInterface
+var _ authapi.ServerInterface = (*AuthHandler)(nil)
+
+// Login exchanges email and password for a token pair
+// (POST /auth/login)
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req apimodels.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, apimodels.Error{
+			Code:    "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	tokens, err := h.sessions.Login(c.Request.Context(), string(req.Email), req.Password)
+	if err != nil {
+		respondSessionError(c, err, "invalid_credentials", "Invalid email or password")
+		return
+	}
+
+	c.JSON(http.StatusOK, tokenPairToAPI(tokens))
+}
+
+// RefreshToken rotates a refresh token into a new token pair
+// (POST /auth/refresh)
+func (h *AuthHandler) RefreshToken(c *gin.Context) {
+	var req apimodels.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, apimodels.Error{
+			Code:    "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	tokens, err := h.sessions.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		respondSessionError(c, err, "invalid_refresh_token", "Refresh token is invalid, expired or revoked")
+		return
+	}
+
+	c.JSON(http.StatusOK, tokenPairToAPI(tokens))
+}
+
+// Logout revokes a refresh token and every token derived from the same login
+// (POST /auth/logout)
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req apimodels.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, apimodels.Error{
+			Code:    "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.sessions.Logout(c.Request.Context(), req.RefreshToken); err != nil {
+		respondSessionError(c, err, "invalid_refresh_token", "Refresh token is invalid")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondSessionError writes the API error for a failed session operation.
+// Rejected credentials become 401 with code; anything else is logged and
+// reported as 500.
+func respondSessionError(c *gin.Context, err error, code, message string) {
+	if errors.Is(err, auth.ErrInvalidCredentials) || errors.Is(err, auth.ErrInvalidRefreshToken) {
+		apierror.Respond(c, http.StatusUnauthorized, apimodels.Error{
+			Code:    code,
+			Message: message,
+		})
+		return
+	}
+	if apierror.DeadlineExceeded(c, err) {
+		apierror.RespondTimeout(c)
+		return
+	}
+
+	slog.ErrorContext(c.Request.Context(), "Session operation failed", slog.Any("error", err))
+	apierror.Respond(c, http.StatusInternalServerError, apimodels.Error{
+		Code:    "internal_error",
+		Message: "Failed to process the request",
+	})
+}
+
+// tokenPairToAPI converts an issued token pair to its API representation
+func tokenPairToAPI(tokens *auth.TokenPair) apimodels.TokenResponse {
+	return apimodels.TokenResponse{
+		AccessToken:  tokens.AccessToken,
+		TokenType:    apimodels.Bearer,
+		ExpiresIn:    int32(tokens.ExpiresIn.Seconds()),
+		RefreshToken: tokens.RefreshToken,
+	}
+}
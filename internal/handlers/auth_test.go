@@ -0,0 +1,138 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"oapi-codegen-layout/internal/testutil"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+// login exchanges email and password for a token pair
+func login(t *testing.T, s *testutil.Server, email, password string) *testutil.Response {
+	t.Helper()
+	return s.Do(t, http.MethodPost, "/auth/login", apimodels.LoginRequest{Email: openapi_types.Email(email), Password: password})
+}
+
+func TestAuthLifecycle(t *testing.T) {
+	for name, opts := range map[string][]testutil.Option{
+		"memory": nil,
+		"sqlite": {testutil.WithSQLite()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := testutil.NewServer(t, opts...)
+			password := "correct-horse-42"
+			s.CreateUser(t, apimodels.CreateUserRequest{Email: "jane@example.com", Name: "Jane", Password: &password})
+			adminToken := s.Token
+
+			testutil.ExpectError(t, login(t, s, "jane@example.com", "wrong-horse-42"), http.StatusUnauthorized, "invalid_credentials")
+			testutil.ExpectError(t, login(t, s, "nobody@example.com", password), http.StatusUnauthorized, "invalid_credentials")
+
+			tokens := testutil.Expect[apimodels.TokenResponse](t, login(t, s, "jane@example.com", password), http.StatusOK)
+			if tokens.TokenType != apimodels.Bearer || tokens.ExpiresIn != 900 {
+				t.Fatalf("unexpected token response: %+v", tokens)
+			}
+
+			// The access token carries the scopes of the viewer role
+			s.Token = tokens.AccessToken
+			testutil.Expect[[]apimodels.Product](t, s.Do(t, http.MethodGet, "/products", nil), http.StatusOK)
+			testutil.ExpectError(t,
+				s.Do(t, http.MethodPost, "/products", apimodels.CreateProductRequest{Name: "Widget", Price: 1, Category: "tools"}),
+				http.StatusForbidden, "insufficient_scope")
+			s.Token = adminToken
+
+			refreshed := testutil.Expect[apimodels.TokenResponse](t,
+				s.Do(t, http.MethodPost, "/auth/refresh", apimodels.RefreshTokenRequest{RefreshToken: tokens.RefreshToken}),
+				http.StatusOK)
+			if refreshed.RefreshToken == tokens.RefreshToken {
+				t.Fatal("expected the refresh token to be rotated")
+			}
+
+			// Replaying the rotated token revokes the tokens derived from it
+			testutil.ExpectError(t,
+				s.Do(t, http.MethodPost, "/auth/refresh", apimodels.RefreshTokenRequest{RefreshToken: tokens.RefreshToken}),
+				http.StatusUnauthorized, "invalid_refresh_token")
+			testutil.ExpectError(t,
+				s.Do(t, http.MethodPost, "/auth/refresh", apimodels.RefreshTokenRequest{RefreshToken: refreshed.RefreshToken}),
+				http.StatusUnauthorized, "invalid_refresh_token")
+		})
+	}
+}
+
+func TestAuthLogout(t *testing.T) {
+	s := testutil.NewServer(t)
+	password := "correct-horse-42"
+	s.CreateUser(t, apimodels.CreateUserRequest{Email: "jane@example.com", Name: "Jane", Password: &password})
+
+	first := testutil.Expect[apimodels.TokenResponse](t, login(t, s, "jane@example.com", password), http.StatusOK)
+	second := testutil.Expect[apimodels.TokenResponse](t, login(t, s, "jane@example.com", password), http.StatusOK)
+
+	if resp := s.Do(t, http.MethodPost, "/auth/logout", apimodels.RefreshTokenRequest{RefreshToken: first.RefreshToken}); resp.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d: %s", resp.Code, resp.Body)
+	}
+	testutil.ExpectError(t,
+		s.Do(t, http.MethodPost, "/auth/refresh", apimodels.RefreshTokenRequest{RefreshToken: first.RefreshToken}),
+		http.StatusUnauthorized, "invalid_refresh_token")
+	testutil.ExpectError(t,
+		s.Do(t, http.MethodPost, "/auth/logout", apimodels.RefreshTokenRequest{RefreshToken: "rt_unknown"}),
+		http.StatusUnauthorized, "invalid_refresh_token")
+
+	// Other logins of the same user are not affected
+	testutil.Expect[apimodels.TokenResponse](t,
+		s.Do(t, http.MethodPost, "/auth/refresh", apimodels.RefreshTokenRequest{RefreshToken: second.RefreshToken}),
+		http.StatusOK)
+}
+
+func TestUserPasswordPolicy(t *testing.T) {
+	s := testutil.NewServer(t)
+
+	weak := "lettersonlypassword"
+	apiErr := testutil.ExpectError(t,
+		s.Do(t, http.MethodPost, "/users", apimodels.CreateUserRequest{Email: "jane@example.com", Name: "Jane", Password: &weak}),
+		http.StatusBadRequest, "weak_password")
+	if apiErr.Field == nil || *apiErr.Field != "password" {
+		t.Fatalf("expected field password, got %v", apiErr.Field)
+	}
+
+	user := s.CreateUser(t, apimodels.CreateUserRequest{Email: "jane@example.com", Name: "Jane"})
+	testutil.ExpectError(t, login(t, s, "jane@example.com", "correct-horse-42"), http.StatusUnauthorized, "invalid_credentials")
+
+	password := "correct-horse-42"
+	testutil.Expect[apimodels.User](t,
+		s.Do(t, http.MethodPut, "/users/"+user.Id.String(), apimodels.UpdateUserRequest{Password: &password}),
+		http.StatusOK)
+	testutil.Expect[apimodels.TokenResponse](t, login(t, s, "jane@example.com", password), http.StatusOK)
+}
+
+func TestPasswordChangeRevokesRefreshTokens(t *testing.T) {
+	for name, opts := range map[string][]testutil.Option{
+		"memory": nil,
+		"sqlite": {testutil.WithSQLite()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := testutil.NewServer(t, opts...)
+			password := "correct-horse-42"
+			user := s.CreateUser(t, apimodels.CreateUserRequest{Email: "jane@example.com", Name: "Jane", Password: &password})
+			tokens := testutil.Expect[apimodels.TokenResponse](t, login(t, s, "jane@example.com", password), http.StatusOK)
+
+			// Other changes keep the sessions of the user
+			newName := "Jane Doe"
+			testutil.Expect[apimodels.User](t,
+				s.Do(t, http.MethodPut, "/users/"+user.Id.String(), apimodels.UpdateUserRequest{Name: &newName}),
+				http.StatusOK)
+			tokens = testutil.Expect[apimodels.TokenResponse](t,
+				s.Do(t, http.MethodPost, "/auth/refresh", apimodels.RefreshTokenRequest{RefreshToken: tokens.RefreshToken}),
+				http.StatusOK)
+
+			newPassword := "battery-staple-43"
+			testutil.Expect[apimodels.User](t,
+				s.Do(t, http.MethodPut, "/users/"+user.Id.String(), apimodels.UpdateUserRequest{Password: &newPassword}),
+				http.StatusOK)
+			testutil.ExpectError(t,
+				s.Do(t, http.MethodPost, "/auth/refresh", apimodels.RefreshTokenRequest{RefreshToken: tokens.RefreshToken}),
+				http.StatusUnauthorized, "invalid_refresh_token")
+			testutil.Expect[apimodels.TokenResponse](t, login(t, s, "jane@example.com", newPassword), http.StatusOK)
+		})
+	}
+}
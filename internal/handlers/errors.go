@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"oapi-codegen-layout/internal/apierror"
+	"oapi-codegen-layout/internal/repository"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+// respondRepositoryError writes the API error matching a repository failure.
+// resource names the entity in not-found and conflict messages, and
+// failureMessage is used for unexpected database errors.
+func respondRepositoryError(c *gin.Context, err error, resource, failureMessage string) {
+	switch {
+	case apierror.DeadlineExceeded(c, err):
+		apierror.RespondTimeout(c)
+	case errors.Is(err, repository.ErrNotFound):
+		apierror.Respond(c, http.StatusNotFound, apimodels.Error{
+			Code:    "not_found",
+			Message: resource + " not found",
+		})
+	case errors.Is(err, repository.ErrConflict):
+		apiErr := apimodels.Error{
+			Code:    "conflict",
+			Message: resource + " conflicts with an existing record",
+		}
+		var conflict *repository.ConflictError
+		if errors.As(err, &conflict) {
+			apiErr.Field = &conflict.Field
+		}
+		apierror.Respond(c, http.StatusConflict, apiErr)
+	default:
+		apierror.Respond(c, http.StatusInternalServerError, apimodels.Error{
+			Code:    "database_error",
+			Message: failureMessage,
+		})
+	}
+}
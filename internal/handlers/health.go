@@ -1,32 +1,113 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"oapi-codegen-layout/internal/healthcheck"
 	apimodels "oapi-codegen-layout/pkg/api/models"
 	"oapi-codegen-layout/pkg/api/health"
 )
 
+// statusShuttingDown is reported by the readiness probe while the server drains
+const statusShuttingDown = "shutting_down"
+
 // HealthHandler implements the health.ServerInterface generated by oapi-codegen
-type HealthHandler struct{}
+type HealthHandler struct {
+	checks   *healthcheck.Registry
+	draining *atomic.Bool
+}
 
-// NewHealthHandler creates a new health handler
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+// NewHealthHandler creates a new health handler that runs checks for the
+// readiness probe; nil runs no checks. While draining is set the readiness
+// probe fails, so load balancers stop sending new requests; nil means the
+// server never drains.
+func NewHealthHandler(checks *healthcheck.Registry, draining *atomic.Bool) *HealthHandler {
+	if checks == nil {
+		checks = healthcheck.NewRegistry(0)
+	}
+	if draining == nil {
+		draining = new(atomic.Bool)
+	}
+	return &HealthHandler{
+		checks:   checks,
+		draining: draining,
+	}
 }
 
 // Ensure HealthHandler implements health.ServerInterface
 var _ health.ServerInterface = (*HealthHandler)(nil)
 
-// GetHealth implements the health check endpoint
+// GetHealth runs the dependency checks like GetReadiness
 // (GET /health)
 func (h *HealthHandler) GetHealth(c *gin.Context) {
-	now := time.Now()
+	h.GetReadiness(c)
+}
+
+// GetLiveness reports that the process is serving requests
+// (GET /health/live)
+func (h *HealthHandler) GetLiveness(c *gin.Context) {
+	c.JSON(http.StatusOK, apimodels.HealthResponse{
+		Status:    healthcheck.StatusOK,
+		Timestamp: time.Now(),
+	})
+}
+
+// GetReadiness runs the dependency checks and fails when a critical one
+// fails or the server is shutting down
+// (GET /health/ready)
+func (h *HealthHandler) GetReadiness(c *gin.Context) {
+	if h.draining.Load() {
+		c.JSON(http.StatusServiceUnavailable, apimodels.HealthResponse{
+			Status:    statusShuttingDown,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	report := h.checks.Run(c.Request.Context())
 	response := apimodels.HealthResponse{
-		Status:    "ok",
-		Timestamp: now,
+		Status:    report.Status(),
+		Timestamp: time.Now(),
+		Checks:    healthChecksToAPI(report),
+	}
+
+	status := http.StatusOK
+	if response.Status == healthcheck.StatusUnavailable {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, response)
+}
+
+// Reasons reported for failed checks. The probes are public, so the error of
+// a check, which may name internal hosts or paths, is only logged.
+const (
+	checkFailedMessage   = "Check failed; see the server logs for details"
+	checkTimedOutMessage = "Check did not complete in time"
+)
+
+// healthChecksToAPI converts check results to their API representation
+func healthChecksToAPI(report healthcheck.Report) *[]apimodels.HealthCheck {
+	checks := make([]apimodels.HealthCheck, len(report))
+	for i, result := range report {
+		checks[i] = apimodels.HealthCheck{
+			Name:      result.Name,
+			Status:    apimodels.HealthCheckOK,
+			Critical:  result.Critical,
+			LatencyMs: float64(result.Latency.Microseconds()) / 1000,
+		}
+		if result.Err != nil {
+			message := checkFailedMessage
+			if errors.Is(result.Err, context.DeadlineExceeded) {
+				message = checkTimedOutMessage
+			}
+			checks[i].Status = apimodels.HealthCheckFailed
+			checks[i].Error = &message
+		}
 	}
-	c.JSON(http.StatusOK, response)
+	return &checks
 }
@@ -0,0 +1,70 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"oapi-codegen-layout/internal/healthcheck"
+	"oapi-codegen-layout/internal/testutil"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+func TestReadinessReportsChecks(t *testing.T) {
+	s := testutil.NewServer(t, testutil.WithSQLite())
+	sqlDB, err := s.DB.DB()
+	if err != nil {
+		t.Fatalf("failed to access database pool: %v", err)
+	}
+	s.Deps.Health.Register("database", true, healthcheck.Ping(sqlDB))
+	s.Deps.Health.Register("disk", false, func(context.Context) error { return errors.New("disk full on /var/lib/app") })
+
+	got := testutil.Expect[apimodels.HealthResponse](t, s.Do(t, http.MethodGet, "/health/ready", nil), http.StatusOK)
+	if got.Status != healthcheck.StatusDegraded || got.Checks == nil || len(*got.Checks) != 2 {
+		t.Fatalf("expected a degraded report with 2 checks, got %+v", got)
+	}
+	database, disk := (*got.Checks)[0], (*got.Checks)[1]
+	if database.Name != "database" || database.Status != apimodels.HealthCheckOK || !database.Critical {
+		t.Fatalf("unexpected database check: %+v", database)
+	}
+	if disk.Status != apimodels.HealthCheckFailed || disk.Error == nil {
+		t.Fatalf("unexpected disk check: %+v", disk)
+	}
+	// Check errors may reveal internal paths and hosts, so they are only logged
+	if strings.Contains(*disk.Error, "/var/lib/app") {
+		t.Fatalf("expected a generic error for the disk check, got %q", *disk.Error)
+	}
+
+	// Once the database is gone the critical check fails the probe
+	sqlDB.Close()
+	got = testutil.Expect[apimodels.HealthResponse](t, s.Do(t, http.MethodGet, "/health/ready", nil), http.StatusServiceUnavailable)
+	if got.Status != healthcheck.StatusUnavailable {
+		t.Fatalf("expected status unavailable, got %q", got.Status)
+	}
+
+	// Liveness does not depend on the database
+	got = testutil.Expect[apimodels.HealthResponse](t, s.Do(t, http.MethodGet, "/health/live", nil), http.StatusOK)
+	if got.Status != healthcheck.StatusOK || got.Checks != nil {
+		t.Fatalf("expected a plain ok liveness report, got %+v", got)
+	}
+}
+
+func TestReadinessFailsWhileDraining(t *testing.T) {
+	s := testutil.NewServer(t)
+
+	got := testutil.Expect[apimodels.HealthResponse](t, s.Do(t, http.MethodGet, "/health", nil), http.StatusOK)
+	if got.Status != healthcheck.StatusOK {
+		t.Fatalf("expected status ok, got %q", got.Status)
+	}
+
+	s.Deps.Draining.Store(true)
+	for _, path := range []string{"/health", "/health/ready"} {
+		got = testutil.Expect[apimodels.HealthResponse](t, s.Do(t, http.MethodGet, path, nil), http.StatusServiceUnavailable)
+		if got.Status != "shutting_down" {
+			t.Fatalf("expected status shutting_down from %s, got %q", path, got.Status)
+		}
+	}
+	testutil.Expect[apimodels.HealthResponse](t, s.Do(t, http.MethodGet, "/health/live", nil), http.StatusOK)
+}
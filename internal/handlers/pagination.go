@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"oapi-codegen-layout/internal/apierror"
+	"oapi-codegen-layout/internal/pagination"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+// parsePage resolves the page size and starting cursor of a list request.
+// It writes a 400 response and returns false when the cursor is invalid.
+func parsePage(c *gin.Context, cursors *pagination.Codec, limit *int32, cursor *string) (int, *pagination.Cursor, bool) {
+	pageSize := pagination.DefaultLimit
+	if limit != nil {
+		pageSize = min(max(int(*limit), 1), pagination.MaxLimit)
+	}
+
+	if cursor == nil || *cursor == "" {
+		return pageSize, nil, true
+	}
+
+	after, err := cursors.Decode(*cursor)
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, apimodels.Error{
+			Code:    "invalid_cursor",
+			Message: "The cursor is malformed or was not issued by this server",
+		})
+		return 0, nil, false
+	}
+	return pageSize, &after, true
+}
+
+// setNextPage advertises the page following last through the X-Next-Cursor
+// and Link response headers
+func setNextPage(c *gin.Context, cursors *pagination.Codec, last pagination.Cursor) {
+	next := cursors.Encode(last)
+
+	u := *c.Request.URL
+	query := u.Query()
+	query.Set("cursor", next)
+	u.RawQuery = query.Encode()
+
+	c.Header(pagination.HeaderNextCursor, next)
+	c.Header(pagination.HeaderLink, fmt.Sprintf(`<%s>; rel="next"`, u.RequestURI()))
+}
@@ -7,21 +7,25 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	openapi_types "github.com/oapi-codegen/runtime/types"
-	"gorm.io/gorm"
+	"oapi-codegen-layout/internal/apierror"
 	"oapi-codegen-layout/internal/models"
+	"oapi-codegen-layout/internal/pagination"
+	"oapi-codegen-layout/internal/repository"
 	apimodels "oapi-codegen-layout/pkg/api/models"
 	"oapi-codegen-layout/pkg/api/products"
 )
 
 // ProductHandler implements the products.ServerInterface generated by oapi-codegen
 type ProductHandler struct {
-	db *gorm.DB
+	repo    repository.ProductRepository
+	cursors *pagination.Codec
 }
 
 // NewProductHandler creates a new product handler
-func NewProductHandler(db *gorm.DB) *ProductHandler {
+func NewProductHandler(repo repository.ProductRepository, cursors *pagination.Codec) *ProductHandler {
 	return &ProductHandler{
-		db: db,
+		repo:    repo,
+		cursors: cursors,
 	}
 }
 
@@ -31,28 +35,35 @@ var _ products.ServerInterface = (*ProductHandler)(nil)
 // ListProducts returns a list of products
 // (GET /products)
 func (h *ProductHandler) ListProducts(c *gin.Context, params products.ListProductsParams) {
-	var dbProducts []models.Product
-
-	query := h.db
+	var filter repository.ProductFilter
 
 	// Apply category filter if provided
-	if params.Category != nil && *params.Category != "" {
-		query = query.Where("category = ?", *params.Category)
+	if params.Category != nil {
+		filter.Category = *params.Category
 	}
 
-	// Apply limit if provided
-	if params.Limit != nil {
-		query = query.Limit(int(*params.Limit))
+	// Resolve page size and cursor
+	limit, after, ok := parsePage(c, h.cursors, params.Limit, params.Cursor)
+	if !ok {
+		return
 	}
+	filter.After = after
 
-	if err := query.Find(&dbProducts).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, apimodels.Error{
-			Code:    "database_error",
-			Message: "Failed to retrieve products",
-		})
+	// Fetch one extra product to learn whether another page follows
+	filter.Limit = limit + 1
+
+	dbProducts, err := h.repo.List(c.Request.Context(), filter)
+	if err != nil {
+		respondRepositoryError(c, err, "Product", "Failed to retrieve products")
 		return
 	}
 
+	if len(dbProducts) > limit {
+		dbProducts = dbProducts[:limit]
+		last := dbProducts[limit-1]
+		setNextPage(c, h.cursors, pagination.Cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
 	// Convert database products to API products
 	apiProducts := make([]apimodels.Product, len(dbProducts))
 	for i, dbProduct := range dbProducts {
@@ -67,7 +78,7 @@ func (h *ProductHandler) ListProducts(c *gin.Context, params products.ListProduc
 func (h *ProductHandler) CreateProduct(c *gin.Context) {
 	var req apimodels.CreateProductRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, apimodels.Error{
+		apierror.Respond(c, http.StatusBadRequest, apimodels.Error{
 			Code:    "invalid_request",
 			Message: err.Error(),
 		})
@@ -78,11 +89,8 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 	dbProduct := apiCreateProductToDBProduct(&req)
 
 	// Create product in database
-	if err := h.db.Create(dbProduct).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, apimodels.Error{
-			Code:    "database_error",
-			Message: "Failed to create product",
-		})
+	if err := h.repo.Create(c.Request.Context(), dbProduct); err != nil {
+		respondRepositoryError(c, err, "Product", "Failed to create product")
 		return
 	}
 
@@ -95,26 +103,14 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 // GetProductById retrieves a product by ID
 // (GET /products/{productId})
 func (h *ProductHandler) GetProductById(c *gin.Context, productId openapi_types.UUID) {
-	var dbProduct models.Product
-
-	// Query product by ID
-	if err := h.db.Where("id = ?", uuid.UUID(productId)).First(&dbProduct).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, apimodels.Error{
-				Code:    "not_found",
-				Message: "Product not found",
-			})
-		} else {
-			c.JSON(http.StatusInternalServerError, apimodels.Error{
-				Code:    "database_error",
-				Message: "Failed to retrieve product",
-			})
-		}
+	dbProduct, err := h.repo.Get(c.Request.Context(), uuid.UUID(productId))
+	if err != nil {
+		respondRepositoryError(c, err, "Product", "Failed to retrieve product")
 		return
 	}
 
 	// Convert database model to API model
-	product := dbProductToAPIProduct(&dbProduct)
+	product := dbProductToAPIProduct(dbProduct)
 
 	c.JSON(http.StatusOK, product)
 }
@@ -124,28 +120,16 @@ func (h *ProductHandler) GetProductById(c *gin.Context, productId openapi_types.
 func (h *ProductHandler) UpdateProduct(c *gin.Context, productId openapi_types.UUID) {
 	var req apimodels.UpdateProductRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, apimodels.Error{
+		apierror.Respond(c, http.StatusBadRequest, apimodels.Error{
 			Code:    "invalid_request",
 			Message: err.Error(),
 		})
 		return
 	}
 
-	var dbProduct models.Product
-
-	// Query product by ID
-	if err := h.db.Where("id = ?", uuid.UUID(productId)).First(&dbProduct).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, apimodels.Error{
-				Code:    "not_found",
-				Message: "Product not found",
-			})
-		} else {
-			c.JSON(http.StatusInternalServerError, apimodels.Error{
-				Code:    "database_error",
-				Message: "Failed to retrieve product",
-			})
-		}
+	dbProduct, err := h.repo.Get(c.Request.Context(), uuid.UUID(productId))
+	if err != nil {
+		respondRepositoryError(c, err, "Product", "Failed to retrieve product")
 		return
 	}
 
@@ -167,16 +151,13 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context, productId openapi_types.U
 	}
 
 	// Save updated product
-	if err := h.db.Save(&dbProduct).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, apimodels.Error{
-			Code:    "database_error",
-			Message: "Failed to update product",
-		})
+	if err := h.repo.Update(c.Request.Context(), dbProduct); err != nil {
+		respondRepositoryError(c, err, "Product", "Failed to update product")
 		return
 	}
 
 	// Convert database model to API model
-	product := dbProductToAPIProduct(&dbProduct)
+	product := dbProductToAPIProduct(dbProduct)
 
 	c.JSON(http.StatusOK, product)
 }
@@ -184,30 +165,9 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context, productId openapi_types.U
 // DeleteProduct deletes a product
 // (DELETE /products/{productId})
 func (h *ProductHandler) DeleteProduct(c *gin.Context, productId openapi_types.UUID) {
-	var dbProduct models.Product
-
-	// Query product by ID
-	if err := h.db.Where("id = ?", uuid.UUID(productId)).First(&dbProduct).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, apimodels.Error{
-				Code:    "not_found",
-				Message: "Product not found",
-			})
-		} else {
-			c.JSON(http.StatusInternalServerError, apimodels.Error{
-				Code:    "database_error",
-				Message: "Failed to retrieve product",
-			})
-		}
-		return
-	}
-
 	// Delete product (soft delete by default with GORM)
-	if err := h.db.Delete(&dbProduct).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, apimodels.Error{
-			Code:    "database_error",
-			Message: "Failed to delete product",
-		})
+	if err := h.repo.Delete(c.Request.Context(), uuid.UUID(productId)); err != nil {
+		respondRepositoryError(c, err, "Product", "Failed to delete product")
 		return
 	}
 
@@ -0,0 +1,128 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"oapi-codegen-layout/internal/testutil"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+func TestProductLifecycle(t *testing.T) {
+	for name, opts := range map[string][]testutil.Option{
+		"memory": nil,
+		"sqlite": {testutil.WithSQLite()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := testutil.NewServer(t, opts...)
+
+			stock := int32(5)
+			created := s.CreateProduct(t, apimodels.CreateProductRequest{
+				Name:     "Laptop",
+				Price:    1299.99,
+				Category: "electronics",
+				Stock:    &stock,
+			})
+			if created.Price != 1299.99 || created.Stock == nil || *created.Stock != 5 {
+				t.Fatalf("unexpected product: %+v", created)
+			}
+
+			price := 999.5
+			updated := testutil.Expect[apimodels.Product](t,
+				s.Do(t, http.MethodPut, "/products/"+created.Id.String(), apimodels.UpdateProductRequest{Price: &price}),
+				http.StatusOK)
+			if updated.Price != price || updated.Name != created.Name {
+				t.Fatalf("unexpected updated product: %+v", updated)
+			}
+
+			got := testutil.Expect[apimodels.Product](t, s.Do(t, http.MethodGet, "/products/"+created.Id.String(), nil), http.StatusOK)
+			if got.Price != price {
+				t.Fatalf("expected price %v, got %v", price, got.Price)
+			}
+
+			if resp := s.Do(t, http.MethodDelete, "/products/"+created.Id.String(), nil); resp.Code != http.StatusNoContent {
+				t.Fatalf("expected status 204, got %d: %s", resp.Code, resp.Body)
+			}
+			testutil.ExpectError(t, s.Do(t, http.MethodGet, "/products/"+created.Id.String(), nil), http.StatusNotFound, "not_found")
+		})
+	}
+}
+
+func TestListProductsByCategory(t *testing.T) {
+	s := testutil.NewServer(t)
+	s.CreateProduct(t, apimodels.CreateProductRequest{Name: "Laptop", Price: 1000, Category: "electronics"})
+	s.CreateProduct(t, apimodels.CreateProductRequest{Name: "Phone", Price: 500, Category: "electronics"})
+	s.CreateProduct(t, apimodels.CreateProductRequest{Name: "Chair", Price: 100, Category: "furniture"})
+
+	list := testutil.Expect[[]apimodels.Product](t, s.Do(t, http.MethodGet, "/products?category=electronics", nil), http.StatusOK)
+	if len(list) != 2 {
+		t.Fatalf("expected 2 products, got %d", len(list))
+	}
+	for _, product := range list {
+		if product.Category != "electronics" {
+			t.Fatalf("unexpected category %q", product.Category)
+		}
+	}
+}
+
+func TestListProductsPagination(t *testing.T) {
+	for name, opts := range map[string][]testutil.Option{
+		"memory": nil,
+		"sqlite": {testutil.WithSQLite()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := testutil.NewServer(t, opts...)
+			for i := range 5 {
+				s.CreateProduct(t, apimodels.CreateProductRequest{Name: fmt.Sprintf("Laptop %d", i), Price: 1000, Category: "electronics"})
+				if i%2 == 0 {
+					s.CreateProduct(t, apimodels.CreateProductRequest{Name: fmt.Sprintf("Chair %d", i), Price: 100, Category: "furniture"})
+				}
+			}
+
+			seen := make(map[openapi_types.UUID]bool)
+			path := "/products?category=electronics&limit=2"
+			pages := 0
+			for path != "" {
+				resp := s.Do(t, http.MethodGet, path, nil)
+				page := testutil.Expect[[]apimodels.Product](t, resp, http.StatusOK)
+				for _, product := range page {
+					if product.Category != "electronics" {
+						t.Fatalf("unexpected category %q", product.Category)
+					}
+					if seen[product.Id] {
+						t.Fatalf("product %s returned twice", product.Id)
+					}
+					seen[product.Id] = true
+				}
+				pages++
+
+				path = ""
+				if next := resp.Header.Get("X-Next-Cursor"); next != "" {
+					// The next link keeps the filter of the request
+					if link := resp.Header.Get("Link"); !strings.Contains(link, `rel="next"`) || !strings.Contains(link, "category=electronics") {
+						t.Fatalf("expected a next link with the category filter, got %q", link)
+					}
+					path = "/products?category=electronics&limit=2&cursor=" + url.QueryEscape(next)
+				}
+			}
+
+			if len(seen) != 5 || pages != 3 {
+				t.Fatalf("expected 5 products over 3 pages, got %d products over %d pages", len(seen), pages)
+			}
+		})
+	}
+}
+
+func TestUpdateProductNotFound(t *testing.T) {
+	s := testutil.NewServer(t)
+	name := "Missing"
+
+	testutil.ExpectError(t,
+		s.Do(t, http.MethodPut, "/products/"+uuid.NewString(), apimodels.UpdateProductRequest{Name: &name}),
+		http.StatusNotFound, "not_found")
+}
@@ -1,99 +1,208 @@
 package handlers
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gin-gonic/gin"
-	"oapi-codegen-layout/pkg/api/health"
-	"oapi-codegen-layout/pkg/api/products"
-	"oapi-codegen-layout/pkg/api/users"
+	"oapi-codegen-layout/internal/apierror"
+	"oapi-codegen-layout/internal/apispec"
+	apimodels "oapi-codegen-layout/pkg/api/models"
 )
 
+// serverURLPlaceholder stands in for the server URL while a document is
+// encoded. The URL of each request is spliced in when the document is served,
+// so documents are only encoded once.
+const serverURLPlaceholder = "SERVER_URL_PLACEHOLDER"
+
+// Content types of the served documents by file extension
+var specContentTypes = map[string]string{
+	"json": "application/json; charset=utf-8",
+	"yaml": "application/yaml; charset=utf-8",
+}
+
+// encodedSpec is an OpenAPI document encoded around its server URL
+type encodedSpec struct {
+	contentType string
+	head, tail  []byte
+	// sum identifies the document independently of the server URL
+	sum [sha256.Size]byte
+}
+
+// SpecHandler serves the merged OpenAPI document of all domains and the
+// document of each domain as JSON and YAML. The server URL of the documents
+// is the configured public URL or the URL the client reached the server at.
+type SpecHandler struct {
+	publicURL string
+	basePath  string
+	merged    map[string]*encodedSpec
+	domains   map[string]map[string]*encodedSpec
+}
+
+// NewSpecHandler encodes the merged document and the document of every
+// domain and creates a handler serving them. basePath is appended to the
+// server URL.
+func NewSpecHandler(merged *openapi3.T, domains []apispec.Domain, publicURL, basePath string) (*SpecHandler, error) {
+	h := &SpecHandler{
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+		basePath:  basePath,
+		domains:   make(map[string]map[string]*encodedSpec, len(domains)),
+	}
+
+	var err error
+	if h.merged, err = encodeSpec(merged); err != nil {
+		return nil, fmt.Errorf("failed to encode merged OpenAPI spec: %w", err)
+	}
+	for _, domain := range domains {
+		if h.domains[domain.Name], err = encodeSpec(domain.Spec); err != nil {
+			return nil, fmt.Errorf("failed to encode %s OpenAPI spec: %w", domain.Name, err)
+		}
+	}
+	return h, nil
+}
+
 // GetSwaggerJSON serves the combined OpenAPI specification as JSON
-func GetSwaggerJSON(c *gin.Context) {
-	// Get specs from all handlers
-	usersSwagger, err := users.GetSwagger()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to load users OpenAPI spec",
+// (GET /openapi.json)
+func (h *SpecHandler) GetSwaggerJSON(c *gin.Context) {
+	h.serve(c, h.merged["json"])
+}
+
+// GetSwaggerYAML serves the combined OpenAPI specification as YAML
+// (GET /openapi.yaml)
+func (h *SpecHandler) GetSwaggerYAML(c *gin.Context) {
+	h.serve(c, h.merged["yaml"])
+}
+
+// GetDomainSpec serves the OpenAPI specification of a single domain, such
+// as users.json or products.yaml
+// (GET /openapi/{document})
+func (h *SpecHandler) GetDomainSpec(c *gin.Context) {
+	document := c.Param("document")
+	name, format, _ := strings.Cut(document, ".")
+	spec, ok := h.domains[name][format]
+	if !ok {
+		apierror.Respond(c, http.StatusNotFound, apimodels.Error{
+			Code:    "not_found",
+			Message: fmt.Sprintf("No OpenAPI document named %s", document),
 		})
 		return
 	}
+	h.serve(c, spec)
+}
 
-	productsSwagger, err := products.GetSwagger()
+// serve writes a document with the server URL of the request. Clients that
+// send its current ETag in If-None-Match get 304 Not Modified.
+func (h *SpecHandler) serve(c *gin.Context, spec *encodedSpec) {
+	// The URL is inserted as JSON string, which is also a valid YAML scalar
+	serverURL, err := json.Marshal(h.serverURL(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to load products OpenAPI spec",
+		apierror.Respond(c, http.StatusInternalServerError, apimodels.Error{
+			Code:    "internal_error",
+			Message: "Failed to encode the server URL",
 		})
 		return
 	}
 
-	healthSwagger, err := health.GetSwagger()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to load health OpenAPI spec",
-		})
+	etagSum := sha256.Sum256(append(spec.sum[:], serverURL...))
+	etag := `"` + hex.EncodeToString(etagSum[:16]) + `"`
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Vary", "Host, X-Forwarded-Proto, X-Forwarded-Host, X-Forwarded-Prefix")
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
 		return
 	}
 
-	// Create combined spec
-	combined := &openapi3.T{
-		OpenAPI: "3.0.3",
-		Info: &openapi3.Info{
-			Title:       "Example API",
-			Description: "Example API using oapi-codegen with Gin - Split Handlers",
-			Version:     "1.0.0",
-		},
-		Servers: openapi3.Servers{
-			{
-				URL:         "http://localhost:8080/api/v1",
-				Description: "Development server",
-			},
-		},
-		Paths:      openapi3.NewPaths(),
-		Components: &openapi3.Components{
-			Schemas: make(openapi3.Schemas),
-		},
-	}
+	c.Header("Content-Type", spec.contentType)
+	c.Status(http.StatusOK)
+	c.Writer.Write(spec.head)
+	c.Writer.Write(serverURL)
+	c.Writer.Write(spec.tail)
+}
 
-	// Merge paths from all handlers
-	if usersSwagger.Paths != nil {
-		for path, pathItem := range usersSwagger.Paths.Map() {
-			combined.Paths.Set(path, pathItem)
-		}
+// serverURL returns the URL of the API: the public URL when configured,
+// otherwise the URL the client reached the server at, taking proxies into
+// account through their X-Forwarded-* headers
+func (h *SpecHandler) serverURL(c *gin.Context) string {
+	if h.publicURL != "" {
+		return h.publicURL + h.basePath
 	}
 
-	if productsSwagger.Paths != nil {
-		for path, pathItem := range productsSwagger.Paths.Map() {
-			combined.Paths.Set(path, pathItem)
-		}
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
 	}
-
-	if healthSwagger.Paths != nil {
-		for path, pathItem := range healthSwagger.Paths.Map() {
-			combined.Paths.Set(path, pathItem)
-		}
+	if proto := forwardedHeader(c, "X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+	host := c.Request.Host
+	if forwardedHost := forwardedHeader(c, "X-Forwarded-Host"); forwardedHost != "" {
+		host = forwardedHost
+	}
+	prefix := strings.Trim(forwardedHeader(c, "X-Forwarded-Prefix"), "/")
+	if prefix != "" {
+		prefix = "/" + prefix
 	}
+	return scheme + "://" + host + prefix + h.basePath
+}
 
-	// Merge schemas from all handlers
-	if usersSwagger.Components != nil && usersSwagger.Components.Schemas != nil {
-		for name, schema := range usersSwagger.Components.Schemas {
-			combined.Components.Schemas[name] = schema
-		}
+// forwardedHeader returns the first value of a X-Forwarded-* header, which
+// was set by the proxy closest to the client
+func forwardedHeader(c *gin.Context, name string) string {
+	first, _, _ := strings.Cut(c.GetHeader(name), ",")
+	return strings.TrimSpace(first)
+}
+
+// encodeSpec encodes spec as JSON and YAML with a placeholder server URL
+func encodeSpec(spec *openapi3.T) (map[string]*encodedSpec, error) {
+	doc := *spec
+	doc.Servers = openapi3.Servers{{URL: serverURLPlaceholder}}
+
+	jsonBody, err := json.Marshal(&doc)
+	if err != nil {
+		return nil, err
+	}
+	yamlBody, err := apispec.EncodeYAML(&doc)
+	if err != nil {
+		return nil, err
 	}
 
-	if productsSwagger.Components != nil && productsSwagger.Components.Schemas != nil {
-		for name, schema := range productsSwagger.Components.Schemas {
-			combined.Components.Schemas[name] = schema
+	encoded := make(map[string]*encodedSpec, len(specContentTypes))
+	for format, body := range map[string][]byte{
+		"json": jsonBody,
+		"yaml": yamlBody,
+	} {
+		placeholder := serverURLPlaceholder
+		if format == "json" {
+			placeholder = `"` + placeholder + `"`
+		}
+		head, tail, ok := bytes.Cut(body, []byte(placeholder))
+		if !ok || bytes.Contains(tail, []byte(placeholder)) {
+			return nil, fmt.Errorf("%s document must contain the server URL placeholder once", format)
+		}
+		encoded[format] = &encodedSpec{
+			contentType: specContentTypes[format],
+			head:        head,
+			tail:        tail,
+			sum:         sha256.Sum256(body),
 		}
 	}
+	return encoded, nil
+}
 
-	if healthSwagger.Components != nil && healthSwagger.Components.Schemas != nil {
-		for name, schema := range healthSwagger.Components.Schemas {
-			combined.Components.Schemas[name] = schema
+// etagMatches reports whether an If-None-Match header lists etag
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
 		}
 	}
-
-	c.JSON(http.StatusOK, combined)
+	return false
 }
@@ -0,0 +1,100 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"oapi-codegen-layout/internal/config"
+	"oapi-codegen-layout/internal/testutil"
+)
+
+// getSpec requests an OpenAPI document and decodes it
+func getSpec(t *testing.T, s *testutil.Server, path string, header http.Header) *openapi3.T {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	resp := s.Serve(req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected status 200 from %s, got %d: %s", path, resp.Code, resp.Body)
+	}
+
+	spec, err := openapi3.NewLoader().LoadFromData(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to decode %s: %v", path, err)
+	}
+	return spec
+}
+
+func TestOpenAPIJSONIsCachedWithETag(t *testing.T) {
+	s := testutil.NewServer(t)
+
+	resp := s.Serve(httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	etag := resp.Header.Get("ETag")
+	if resp.Code != http.StatusOK || etag == "" {
+		t.Fatalf("expected 200 with an ETag, got %d %q", resp.Code, etag)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	req.Header.Set("If-None-Match", etag)
+	resp = s.Serve(req)
+	if resp.Code != http.StatusNotModified || len(resp.Body) != 0 {
+		t.Fatalf("expected 304 without body, got %d with %d bytes", resp.Code, len(resp.Body))
+	}
+
+	// The ETag covers the server URL, which depends on the request
+	req = httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	req.Header.Set("If-None-Match", etag)
+	req.Header.Set("X-Forwarded-Host", "api.example.com")
+	if resp = s.Serve(req); resp.Code != http.StatusOK {
+		t.Fatalf("expected 200 for another server URL, got %d", resp.Code)
+	}
+}
+
+func TestOpenAPIDocuments(t *testing.T) {
+	s := testutil.NewServer(t)
+	forwarded := http.Header{
+		"X-Forwarded-Proto":  {"https"},
+		"X-Forwarded-Host":   {"api.example.com, proxy.internal"},
+		"X-Forwarded-Prefix": {"/shop"},
+	}
+
+	merged := getSpec(t, s, "/openapi.yaml", forwarded)
+	if got := merged.Servers[0].URL; got != "https://api.example.com/shop/api/v1" {
+		t.Fatalf("expected server URL from X-Forwarded-* headers, got %q", got)
+	}
+	if merged.Paths.Find("/users") == nil || merged.Paths.Find("/products") == nil {
+		t.Fatal("expected the merged document to contain every domain")
+	}
+
+	for _, path := range []string{"/openapi/users.json", "/openapi/users.yaml"} {
+		users := getSpec(t, s, path, nil)
+		if users.Paths.Find("/users") == nil || users.Paths.Find("/products") != nil {
+			t.Fatalf("expected %s to contain only the users domain", path)
+		}
+		if got := users.Servers[0].URL; got != "http://example.com/api/v1" {
+			t.Fatalf("expected server URL of the request, got %q", got)
+		}
+	}
+
+	for _, path := range []string{"/openapi/orders.json", "/openapi/users.xml", "/openapi/users"} {
+		testutil.ExpectError(t, s.Serve(httptest.NewRequest(http.MethodGet, path, nil)), http.StatusNotFound, "not_found")
+	}
+}
+
+func TestOpenAPIPublicURL(t *testing.T) {
+	s := testutil.NewServer(t, testutil.WithServerConfig(config.ServerConfig{
+		Mode:      gin.TestMode,
+		PublicURL: "https://api.example.com/",
+	}))
+
+	spec := getSpec(t, s, "/openapi/products.json", http.Header{"X-Forwarded-Host": {"ignored.example.com"}})
+	if got := spec.Servers[0].URL; got != "https://api.example.com/api/v1" {
+		t.Fatalf("expected the configured public URL, got %q", got)
+	}
+}
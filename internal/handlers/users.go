@@ -1,26 +1,36 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	openapi_types "github.com/oapi-codegen/runtime/types"
-	"gorm.io/gorm"
+	"oapi-codegen-layout/internal/apierror"
+	"oapi-codegen-layout/internal/auth"
 	"oapi-codegen-layout/internal/models"
+	"oapi-codegen-layout/internal/pagination"
+	"oapi-codegen-layout/internal/repository"
 	apimodels "oapi-codegen-layout/pkg/api/models"
 	"oapi-codegen-layout/pkg/api/users"
 )
 
 // UserHandler implements the users.ServerInterface generated by oapi-codegen
 type UserHandler struct {
-	db *gorm.DB
+	repo          repository.UserRepository
+	refreshTokens repository.RefreshTokenRepository
+	cursors       *pagination.Codec
 }
 
-// NewUserHandler creates a new user handler
-func NewUserHandler(db *gorm.DB) *UserHandler {
+// NewUserHandler creates a new user handler. The refresh tokens of a user are
+// revoked when their password changes.
+func NewUserHandler(repo repository.UserRepository, refreshTokens repository.RefreshTokenRepository, cursors *pagination.Codec) *UserHandler {
 	return &UserHandler{
-		db: db,
+		repo:          repo,
+		refreshTokens: refreshTokens,
+		cursors:       cursors,
 	}
 }
 
@@ -30,23 +40,30 @@ var _ users.ServerInterface = (*UserHandler)(nil)
 // ListUsers returns a list of users
 // (GET /users)
 func (h *UserHandler) ListUsers(c *gin.Context, params users.ListUsersParams) {
-	var dbUsers []models.User
+	var filter repository.UserFilter
 
-	query := h.db
-
-	// Apply limit if provided
-	if params.Limit != nil {
-		query = query.Limit(int(*params.Limit))
+	// Resolve page size and cursor
+	limit, after, ok := parsePage(c, h.cursors, params.Limit, params.Cursor)
+	if !ok {
+		return
 	}
+	filter.After = after
 
-	if err := query.Find(&dbUsers).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, apimodels.Error{
-			Code:    "database_error",
-			Message: "Failed to retrieve users",
-		})
+	// Fetch one extra user to learn whether another page follows
+	filter.Limit = limit + 1
+
+	dbUsers, err := h.repo.List(c.Request.Context(), filter)
+	if err != nil {
+		respondRepositoryError(c, err, "User", "Failed to retrieve users")
 		return
 	}
 
+	if len(dbUsers) > limit {
+		dbUsers = dbUsers[:limit]
+		last := dbUsers[limit-1]
+		setNextPage(c, h.cursors, pagination.Cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
 	// Convert database users to API users
 	apiUsers := make([]apimodels.User, len(dbUsers))
 	for i, dbUser := range dbUsers {
@@ -61,7 +78,7 @@ func (h *UserHandler) ListUsers(c *gin.Context, params users.ListUsersParams) {
 func (h *UserHandler) CreateUser(c *gin.Context) {
 	var req apimodels.CreateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, apimodels.Error{
+		apierror.Respond(c, http.StatusBadRequest, apimodels.Error{
 			Code:    "invalid_request",
 			Message: err.Error(),
 		})
@@ -70,13 +87,13 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 
 	// Convert API request to database model
 	dbUser := apiCreateUserToDBUser(&req)
+	if req.Password != nil && !setPassword(c, dbUser, *req.Password) {
+		return
+	}
 
 	// Create user in database
-	if err := h.db.Create(dbUser).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, apimodels.Error{
-			Code:    "database_error",
-			Message: "Failed to create user",
-		})
+	if err := h.repo.Create(c.Request.Context(), dbUser); err != nil {
+		respondUserWriteError(c, err, "Failed to create user")
 		return
 	}
 
@@ -89,26 +106,14 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 // GetUserById retrieves a user by ID
 // (GET /users/{userId})
 func (h *UserHandler) GetUserById(c *gin.Context, userId openapi_types.UUID) {
-	var dbUser models.User
-
-	// Query user by ID
-	if err := h.db.Where("id = ?", uuid.UUID(userId)).First(&dbUser).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, apimodels.Error{
-				Code:    "not_found",
-				Message: "User not found",
-			})
-		} else {
-			c.JSON(http.StatusInternalServerError, apimodels.Error{
-				Code:    "database_error",
-				Message: "Failed to retrieve user",
-			})
-		}
+	dbUser, err := h.repo.Get(c.Request.Context(), uuid.UUID(userId))
+	if err != nil {
+		respondRepositoryError(c, err, "User", "Failed to retrieve user")
 		return
 	}
 
 	// Convert database model to API model
-	user := dbUserToAPIUser(&dbUser)
+	user := dbUserToAPIUser(dbUser)
 
 	c.JSON(http.StatusOK, user)
 }
@@ -118,28 +123,16 @@ func (h *UserHandler) GetUserById(c *gin.Context, userId openapi_types.UUID) {
 func (h *UserHandler) UpdateUser(c *gin.Context, userId openapi_types.UUID) {
 	var req apimodels.UpdateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, apimodels.Error{
+		apierror.Respond(c, http.StatusBadRequest, apimodels.Error{
 			Code:    "invalid_request",
 			Message: err.Error(),
 		})
 		return
 	}
 
-	var dbUser models.User
-
-	// Query user by ID
-	if err := h.db.Where("id = ?", uuid.UUID(userId)).First(&dbUser).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, apimodels.Error{
-				Code:    "not_found",
-				Message: "User not found",
-			})
-		} else {
-			c.JSON(http.StatusInternalServerError, apimodels.Error{
-				Code:    "database_error",
-				Message: "Failed to retrieve user",
-			})
-		}
+	dbUser, err := h.repo.Get(c.Request.Context(), uuid.UUID(userId))
+	if err != nil {
+		respondRepositoryError(c, err, "User", "Failed to retrieve user")
 		return
 	}
 
@@ -150,18 +143,32 @@ func (h *UserHandler) UpdateUser(c *gin.Context, userId openapi_types.UUID) {
 	if req.Name != nil {
 		dbUser.Name = *req.Name
 	}
+	if req.Role != nil {
+		dbUser.Role = string(*req.Role)
+	}
+	if req.Password != nil && !setPassword(c, dbUser, *req.Password) {
+		return
+	}
+
+	// A new password ends the sessions started with the old one, so stolen
+	// refresh tokens stop working. They are revoked before the password is
+	// saved: if saving fails the user merely signs in again, whereas the
+	// other order could leave a changed password with live sessions.
+	if req.Password != nil {
+		if err := h.refreshTokens.RevokeUser(c.Request.Context(), dbUser.ID, time.Now()); err != nil {
+			respondRepositoryError(c, err, "User", "Failed to revoke the user's refresh tokens")
+			return
+		}
+	}
 
 	// Save updated user
-	if err := h.db.Save(&dbUser).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, apimodels.Error{
-			Code:    "database_error",
-			Message: "Failed to update user",
-		})
+	if err := h.repo.Update(c.Request.Context(), dbUser); err != nil {
+		respondUserWriteError(c, err, "Failed to update user")
 		return
 	}
 
 	// Convert database model to API model
-	user := dbUserToAPIUser(&dbUser)
+	user := dbUserToAPIUser(dbUser)
 
 	c.JSON(http.StatusOK, user)
 }
@@ -169,34 +176,53 @@ func (h *UserHandler) UpdateUser(c *gin.Context, userId openapi_types.UUID) {
 // DeleteUser deletes a user
 // (DELETE /users/{userId})
 func (h *UserHandler) DeleteUser(c *gin.Context, userId openapi_types.UUID) {
-	var dbUser models.User
-
-	// Query user by ID
-	if err := h.db.Where("id = ?", uuid.UUID(userId)).First(&dbUser).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, apimodels.Error{
-				Code:    "not_found",
-				Message: "User not found",
-			})
-		} else {
-			c.JSON(http.StatusInternalServerError, apimodels.Error{
-				Code:    "database_error",
-				Message: "Failed to retrieve user",
-			})
-		}
+	// Delete user (soft delete by default with GORM)
+	if err := h.repo.Delete(c.Request.Context(), uuid.UUID(userId)); err != nil {
+		respondRepositoryError(c, err, "User", "Failed to delete user")
 		return
 	}
 
-	// Delete user (soft delete by default with GORM)
-	if err := h.db.Delete(&dbUser).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, apimodels.Error{
-			Code:    "database_error",
-			Message: "Failed to delete user",
+	c.Status(http.StatusNoContent)
+}
+
+// setPassword checks a new password against the password policy and stores
+// its hash on dbUser. It writes the API error and returns false on failure.
+func setPassword(c *gin.Context, dbUser *models.User, password string) bool {
+	if err := auth.ValidatePassword(password, dbUser.Email); err != nil {
+		field := "password"
+		apierror.Respond(c, http.StatusBadRequest, apimodels.Error{
+			Code:    "weak_password",
+			Message: err.Error(),
+			Field:   &field,
 		})
-		return
+		return false
 	}
 
-	c.Status(http.StatusNoContent)
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, apimodels.Error{
+			Code:    "internal_error",
+			Message: "Failed to hash password",
+		})
+		return false
+	}
+	dbUser.PasswordHash = hash
+	return true
+}
+
+// respondUserWriteError writes the API error for a failed user insert or
+// update, reporting a taken email address as 409 email_taken
+func respondUserWriteError(c *gin.Context, err error, failureMessage string) {
+	var conflict *repository.ConflictError
+	if errors.As(err, &conflict) && conflict.Field == "email" {
+		apierror.Respond(c, http.StatusConflict, apimodels.Error{
+			Code:    "email_taken",
+			Message: "Email address is already in use",
+			Field:   &conflict.Field,
+		})
+		return
+	}
+	respondRepositoryError(c, err, "User", failureMessage)
 }
 
 // Helper functions to convert between database models and API models
@@ -205,14 +231,21 @@ func dbUserToAPIUser(dbUser *models.User) apimodels.User {
 		Id:        openapi_types.UUID(dbUser.ID),
 		Email:     openapi_types.Email(dbUser.Email),
 		Name:      dbUser.Name,
+		Role:      apimodels.UserRole(dbUser.Role),
 		CreatedAt: dbUser.CreatedAt,
 		UpdatedAt: &dbUser.UpdatedAt,
 	}
 }
 
 func apiCreateUserToDBUser(req *apimodels.CreateUserRequest) *models.User {
+	// New users can only read unless a role is granted explicitly
+	role := apimodels.CreateUserRequestRoleViewer
+	if req.Role != nil {
+		role = *req.Role
+	}
 	return &models.User{
 		Email: string(req.Email),
 		Name:  req.Name,
+		Role:  string(role),
 	}
 }
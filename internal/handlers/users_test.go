@@ -0,0 +1,153 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"oapi-codegen-layout/internal/testutil"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+func TestUserLifecycle(t *testing.T) {
+	for name, opts := range map[string][]testutil.Option{
+		"memory": nil,
+		"sqlite": {testutil.WithSQLite()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := testutil.NewServer(t, opts...)
+
+			created := s.CreateUser(t, apimodels.CreateUserRequest{Email: "jane@example.com", Name: "Jane"})
+			if created.Name != "Jane" || created.Email != "jane@example.com" {
+				t.Fatalf("unexpected user: %+v", created)
+			}
+
+			got := testutil.Expect[apimodels.User](t, s.Do(t, http.MethodGet, "/users/"+created.Id.String(), nil), http.StatusOK)
+			if got.Id != created.Id {
+				t.Fatalf("expected user %s, got %s", created.Id, got.Id)
+			}
+
+			newName := "Jane Doe"
+			updated := testutil.Expect[apimodels.User](t,
+				s.Do(t, http.MethodPut, "/users/"+created.Id.String(), apimodels.UpdateUserRequest{Name: &newName}),
+				http.StatusOK)
+			if updated.Name != newName || updated.Email != created.Email {
+				t.Fatalf("unexpected updated user: %+v", updated)
+			}
+
+			// The list also holds the admin user the test server authenticates as
+			list := testutil.Expect[[]apimodels.User](t, s.Do(t, http.MethodGet, "/users", nil), http.StatusOK)
+			if len(list) != 2 || list[1].Name != newName {
+				t.Fatalf("unexpected user list: %+v", list)
+			}
+
+			if resp := s.Do(t, http.MethodDelete, "/users/"+created.Id.String(), nil); resp.Code != http.StatusNoContent {
+				t.Fatalf("expected status 204, got %d: %s", resp.Code, resp.Body)
+			}
+			testutil.ExpectError(t, s.Do(t, http.MethodGet, "/users/"+created.Id.String(), nil), http.StatusNotFound, "not_found")
+		})
+	}
+}
+
+func TestListUsersLimit(t *testing.T) {
+	s := testutil.NewServer(t)
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		s.CreateUser(t, apimodels.CreateUserRequest{Email: openapi_types.Email(email), Name: "User"})
+	}
+
+	list := testutil.Expect[[]apimodels.User](t, s.Do(t, http.MethodGet, "/users?limit=2", nil), http.StatusOK)
+	if len(list) != 2 {
+		t.Fatalf("expected 2 users, got %d", len(list))
+	}
+}
+
+func TestUserNotFound(t *testing.T) {
+	s := testutil.NewServer(t)
+	id := uuid.NewString()
+
+	testutil.ExpectError(t, s.Do(t, http.MethodGet, "/users/"+id, nil), http.StatusNotFound, "not_found")
+	testutil.ExpectError(t, s.Do(t, http.MethodDelete, "/users/"+id, nil), http.StatusNotFound, "not_found")
+}
+
+func TestCreateUserInvalidBody(t *testing.T) {
+	s := testutil.NewServer(t)
+
+	testutil.ExpectError(t, s.Do(t, http.MethodPost, "/users", map[string]any{"email": 42}), http.StatusBadRequest, "validation_failed")
+}
+
+func TestListUsersPagination(t *testing.T) {
+	for name, opts := range map[string][]testutil.Option{
+		"memory": nil,
+		"sqlite": {testutil.WithSQLite()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := testutil.NewServer(t, opts...)
+			for i := range 5 {
+				s.CreateUser(t, apimodels.CreateUserRequest{Email: openapi_types.Email(fmt.Sprintf("user%d@example.com", i)), Name: "User"})
+			}
+
+			seen := make(map[openapi_types.UUID]bool)
+			path := "/users?limit=2"
+			pages := 0
+			for path != "" {
+				resp := s.Do(t, http.MethodGet, path, nil)
+				page := testutil.Expect[[]apimodels.User](t, resp, http.StatusOK)
+				for _, user := range page {
+					if seen[user.Id] {
+						t.Fatalf("user %s returned twice", user.Id)
+					}
+					seen[user.Id] = true
+				}
+				pages++
+
+				path = ""
+				if next := resp.Header.Get("X-Next-Cursor"); next != "" {
+					if link := resp.Header.Get("Link"); !strings.Contains(link, `rel="next"`) {
+						t.Fatalf("expected a next link, got %q", link)
+					}
+					path = "/users?limit=2&cursor=" + url.QueryEscape(next)
+				}
+			}
+
+			// Five created users plus the admin user of the test server
+			if len(seen) != 6 || pages != 3 {
+				t.Fatalf("expected 6 users over 3 pages, got %d users over %d pages", len(seen), pages)
+			}
+		})
+	}
+}
+
+func TestListUsersInvalidCursor(t *testing.T) {
+	s := testutil.NewServer(t)
+
+	testutil.ExpectError(t, s.Do(t, http.MethodGet, "/users?cursor=forged", nil), http.StatusBadRequest, "invalid_cursor")
+}
+
+func TestUserEmailTaken(t *testing.T) {
+	for name, opts := range map[string][]testutil.Option{
+		"memory": nil,
+		"sqlite": {testutil.WithSQLite()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := testutil.NewServer(t, opts...)
+			s.CreateUser(t, apimodels.CreateUserRequest{Email: "taken@example.com", Name: "First"})
+			other := s.CreateUser(t, apimodels.CreateUserRequest{Email: "other@example.com", Name: "Second"})
+
+			apiErr := testutil.ExpectError(t,
+				s.Do(t, http.MethodPost, "/users", apimodels.CreateUserRequest{Email: "taken@example.com", Name: "Third"}),
+				http.StatusConflict, "email_taken")
+			if apiErr.Field == nil || *apiErr.Field != "email" {
+				t.Fatalf("expected field email, got %v", apiErr.Field)
+			}
+
+			taken := openapi_types.Email("taken@example.com")
+			testutil.ExpectError(t,
+				s.Do(t, http.MethodPut, "/users/"+other.Id.String(), apimodels.UpdateUserRequest{Email: &taken}),
+				http.StatusConflict, "email_taken")
+		})
+	}
+}
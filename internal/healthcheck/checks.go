@@ -0,0 +1,52 @@
+package healthcheck
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"oapi-codegen-layout/internal/database/migrate"
+)
+
+// Ping checks that a connection to db can be established
+func Ping(db *sql.DB) Check {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// Migrations checks that every migration known to the binary has been
+// applied, so the schema matches what the code expects
+func Migrations(migrator *migrate.Migrator) Check {
+	return func(ctx context.Context) error {
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			return err
+		}
+		if pending > 0 {
+			return fmt.Errorf("%d migration(s) pending; run \"server migrate up\"", pending)
+		}
+		return nil
+	}
+}
+
+// DiskSpaceSupported reports whether DiskSpace works on this platform; on
+// others its check always fails
+func DiskSpaceSupported() bool {
+	return diskSpaceSupported
+}
+
+// DiskSpace checks that the file system holding path has at least minFree
+// bytes available
+func DiskSpace(path string, minFree uint64) Check {
+	return func(ctx context.Context) error {
+		free, err := freeSpace(path)
+		if err != nil {
+			return fmt.Errorf("failed to read free space of %s: %w", path, err)
+		}
+		if free < minFree {
+			return fmt.Errorf("%d MiB free on %s, need at least %d MiB", free>>20, path, minFree>>20)
+		}
+		return nil
+	}
+}
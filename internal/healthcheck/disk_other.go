@@ -0,0 +1,13 @@
+//go:build !linux && !darwin
+
+package healthcheck
+
+import "errors"
+
+// diskSpaceSupported reports that DiskSpace cannot read the free space here
+const diskSpaceSupported = false
+
+// freeSpace is not implemented on this platform
+func freeSpace(path string) (uint64, error) {
+	return 0, errors.ErrUnsupported
+}
@@ -0,0 +1,18 @@
+//go:build linux || darwin
+
+package healthcheck
+
+import "syscall"
+
+// diskSpaceSupported reports that DiskSpace can read the free space here
+const diskSpaceSupported = true
+
+// freeSpace returns the bytes available to unprivileged users on the file
+// system holding path
+func freeSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,122 @@
+// Package healthcheck runs the dependency checks behind the readiness probe
+// and aggregates their results.
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Overall status of a Report
+const (
+	StatusOK          = "ok"
+	StatusDegraded    = "degraded"
+	StatusUnavailable = "unavailable"
+)
+
+// Check reports whether a dependency is usable. It should return once ctx
+// is done.
+type Check func(ctx context.Context) error
+
+// Result is the outcome of a single check
+type Result struct {
+	Name     string
+	Critical bool
+	Latency  time.Duration
+	// Err is nil when the check passed
+	Err error
+}
+
+// Report holds the results of a run in registration order
+type Report []Result
+
+// Status returns StatusUnavailable when a critical check failed,
+// StatusDegraded when only non-critical checks failed and StatusOK otherwise
+func (r Report) Status() string {
+	status := StatusOK
+	for _, result := range r {
+		if result.Err == nil {
+			continue
+		}
+		if result.Critical {
+			return StatusUnavailable
+		}
+		status = StatusDegraded
+	}
+	return status
+}
+
+// registered is a check added to a Registry
+type registered struct {
+	name     string
+	critical bool
+	check    Check
+}
+
+// Registry holds the checks run by the readiness probe. Checks are
+// registered at startup; Register must not be called concurrently with Run.
+type Registry struct {
+	timeout time.Duration
+	checks  []registered
+}
+
+// NewRegistry creates an empty registry that gives each check timeout to
+// complete; zero means no timeout
+func NewRegistry(timeout time.Duration) *Registry {
+	return &Registry{timeout: timeout}
+}
+
+// Register adds a check. When a critical check fails the service is
+// reported as unavailable; other failures only degrade it.
+func (r *Registry) Register(name string, critical bool, check Check) {
+	r.checks = append(r.checks, registered{name: name, critical: critical, check: check})
+}
+
+// Run runs all checks concurrently and returns their results. A check that
+// does not return within the timeout is reported as failed without waiting
+// for it.
+func (r *Registry) Run(ctx context.Context) Report {
+	report := make(Report, len(r.checks))
+	var wg sync.WaitGroup
+	for i, c := range r.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report[i] = r.run(ctx, c)
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+// run runs a single check with the registry timeout
+func (r *Registry) run(ctx context.Context, c registered) Result {
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- c.check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("check did not complete: %w", ctx.Err())
+	}
+
+	result := Result{Name: c.name, Critical: c.critical, Latency: time.Since(start), Err: err}
+	if err != nil {
+		slog.WarnContext(ctx, "Health check failed",
+			slog.String("check", c.name), slog.Bool("critical", c.critical), slog.Any("error", err))
+	}
+	return result
+}
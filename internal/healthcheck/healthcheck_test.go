@@ -0,0 +1,69 @@
+package healthcheck_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"oapi-codegen-layout/internal/healthcheck"
+)
+
+func TestRegistryStatus(t *testing.T) {
+	pass := func(context.Context) error { return nil }
+	fail := func(context.Context) error { return errors.New("down") }
+
+	for name, tc := range map[string]struct {
+		critical, optional healthcheck.Check
+		want               string
+	}{
+		"all pass":          {pass, pass, healthcheck.StatusOK},
+		"optional fails":    {pass, fail, healthcheck.StatusDegraded},
+		"critical fails":    {fail, pass, healthcheck.StatusUnavailable},
+		"everything failed": {fail, fail, healthcheck.StatusUnavailable},
+	} {
+		t.Run(name, func(t *testing.T) {
+			checks := healthcheck.NewRegistry(time.Second)
+			checks.Register("critical", true, tc.critical)
+			checks.Register("optional", false, tc.optional)
+
+			report := checks.Run(context.Background())
+			if got := report.Status(); got != tc.want {
+				t.Fatalf("expected status %q, got %q", tc.want, got)
+			}
+			if len(report) != 2 || report[0].Name != "critical" || report[1].Name != "optional" {
+				t.Fatalf("expected results in registration order, got %+v", report)
+			}
+		})
+	}
+}
+
+func TestRegistryTimesOutStuckChecks(t *testing.T) {
+	checks := healthcheck.NewRegistry(20 * time.Millisecond)
+	release := make(chan struct{})
+	defer close(release)
+	checks.Register("stuck", true, func(context.Context) error {
+		// Ignores its context, so Run must not wait for it
+		<-release
+		return nil
+	})
+
+	report := checks.Run(context.Background())
+	if !errors.Is(report[0].Err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", report[0].Err)
+	}
+	if report.Status() != healthcheck.StatusUnavailable {
+		t.Fatalf("expected status unavailable, got %q", report.Status())
+	}
+}
+
+func TestDiskSpace(t *testing.T) {
+	if err := healthcheck.DiskSpace(t.TempDir(), 1)(context.Background()); errors.Is(err, errors.ErrUnsupported) {
+		t.Skip("disk space check is not supported on this platform")
+	} else if err != nil {
+		t.Fatalf("expected a byte to be free, got %v", err)
+	}
+	if err := healthcheck.DiskSpace(t.TempDir(), 1<<62)(context.Background()); err == nil {
+		t.Fatal("expected the check to fail with an unreachable minimum")
+	}
+}
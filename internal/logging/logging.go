@@ -0,0 +1,104 @@
+// Package logging builds the application's slog logger and carries the
+// request ID of a request through its context into every log record.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"gorm.io/gorm/logger"
+	"oapi-codegen-layout/internal/config"
+)
+
+// Supported log formats
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// RequestIDKey is the attribute key of the request ID in log records
+const RequestIDKey = "request_id"
+
+// requestIDKey is the context key under which the request ID is stored
+type requestIDKey struct{}
+
+// New creates a logger that writes records of at least the configured level
+// to w in the configured format. Records logged with a context carrying a
+// request ID get a request_id attribute.
+func New(cfg config.LogConfig, w io.Writer) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q: %w", cfg.Level, err)
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch strings.ToLower(cfg.Format) {
+	case FormatText, "":
+		handler = slog.NewTextHandler(w, options)
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, options)
+	default:
+		return nil, fmt.Errorf("unsupported log format %q; expected text or json", cfg.Format)
+	}
+
+	return slog.New(contextHandler{handler}), nil
+}
+
+// WithRequestID returns a context that carries a request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID carried by ctx
+func RequestID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// contextHandler adds the request ID of the record's context to every record
+type contextHandler struct {
+	slog.Handler
+}
+
+// Handle adds the request ID, if any, and passes the record on
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id, ok := RequestID(ctx); ok {
+		record.AddAttrs(slog.String(RequestIDKey, id))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs keeps the request ID handling for derived loggers
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup keeps the request ID handling for derived loggers
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// slowQueryThreshold is the duration above which GORM queries are logged as warnings
+const slowQueryThreshold = 200 * time.Millisecond
+
+// NewGormLogger returns a GORM logger that writes to log. Failed and slow
+// queries are always logged; every query is logged when log is enabled
+// for debug records. Query parameters are left out, since they may carry
+// credentials.
+func NewGormLogger(log *slog.Logger) logger.Interface {
+	level := logger.Warn
+	if log.Enabled(context.Background(), slog.LevelDebug) {
+		level = logger.Info
+	}
+	return logger.NewSlogLogger(log, logger.Config{
+		LogLevel:                  level,
+		SlowThreshold:             slowQueryThreshold,
+		ParameterizedQueries:      true,
+		IgnoreRecordNotFoundError: true,
+	})
+}
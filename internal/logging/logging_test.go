@@ -0,0 +1,43 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"oapi-codegen-layout/internal/config"
+	"oapi-codegen-layout/internal/logging"
+)
+
+func TestNew(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(config.LogConfig{Level: "warn", Format: "json"}, &buf)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	ctx := logging.WithRequestID(context.Background(), "req-1")
+	logger.InfoContext(ctx, "dropped")
+	logger.With(slog.String("component", "test")).WarnContext(ctx, "kept")
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected a single JSON record, got %q: %v", buf.String(), err)
+	}
+	if record["msg"] != "kept" || record[logging.RequestIDKey] != "req-1" || record["component"] != "test" {
+		t.Fatalf("unexpected record %v", record)
+	}
+}
+
+func TestNewRejectsInvalidConfig(t *testing.T) {
+	for _, cfg := range []config.LogConfig{
+		{Level: "verbose", Format: "text"},
+		{Level: "info", Format: "xml"},
+	} {
+		if _, err := logging.New(cfg, &bytes.Buffer{}); err == nil {
+			t.Errorf("expected %+v to be rejected", cfg)
+		}
+	}
+}
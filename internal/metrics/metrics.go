@@ -0,0 +1,91 @@
+// Package metrics defines the Prometheus metrics of the API and the registry
+// they are exposed from.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// unmatchedOperation labels requests that did not match an operation, so that
+// unknown paths cannot inflate the number of series
+const unmatchedOperation = "unmatched"
+
+// NewRegistry creates a registry with the Go runtime metrics and, when db is
+// not nil, the connection pool statistics of db
+func NewRegistry(db *sql.DB) *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector())
+	if db != nil {
+		registry.MustRegister(collectors.NewDBStatsCollector(db, "main"))
+	}
+	return registry
+}
+
+// Handler serves the metrics of registry in the Prometheus exposition format
+func Handler(registry *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
+}
+
+// HTTP collects request counts and latencies, and responses that do not
+// match the spec, by OpenAPI operation
+type HTTP struct {
+	requests           *prometheus.CounterVec
+	duration           *prometheus.HistogramVec
+	validationFailures *prometheus.CounterVec
+}
+
+// NewHTTP creates the HTTP request metrics and registers them with registerer
+func NewHTTP(registerer prometheus.Registerer) (*HTTP, error) {
+	h := &HTTP{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total number of HTTP requests by operation, method and status class.",
+		}, []string{"operation_id", "method", "status_class"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Latency of HTTP requests by operation and method.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"operation_id", "method"}),
+		validationFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "response_validation_failures_total",
+			Help: "Total number of responses that do not match the API specification by operation.",
+		}, []string{"operation_id"}),
+	}
+	for _, c := range []prometheus.Collector{h.requests, h.duration, h.validationFailures} {
+		if err := registerer.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// Observe records a finished request. operationID is empty for requests that
+// did not match an operation.
+func (h *HTTP) Observe(operationID, method string, status int, latency time.Duration) {
+	if operationID == "" {
+		operationID = unmatchedOperation
+	}
+	h.requests.WithLabelValues(operationID, method, StatusClass(status)).Inc()
+	h.duration.WithLabelValues(operationID, method).Observe(latency.Seconds())
+}
+
+// ValidationFailures returns the counter of responses that do not match the
+// spec, labelled by operation_id
+func (h *HTTP) ValidationFailures() *prometheus.CounterVec {
+	return h.validationFailures
+}
+
+// StatusClass returns the class of an HTTP status code, such as "2xx"
+func StatusClass(status int) string {
+	if status < 100 || status > 599 {
+		return "unknown"
+	}
+	return strconv.Itoa(status/100) + "xx"
+}
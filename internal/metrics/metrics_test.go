@@ -0,0 +1,52 @@
+package metrics_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"oapi-codegen-layout/internal/metrics"
+)
+
+func TestHTTP(t *testing.T) {
+	registry := metrics.NewRegistry(nil)
+	h, err := metrics.NewHTTP(registry)
+	if err != nil {
+		t.Fatalf("NewHTTP: %v", err)
+	}
+	h.Observe("ListProducts", http.MethodGet, http.StatusOK, 20*time.Millisecond)
+	h.Observe("ListProducts", http.MethodGet, http.StatusNoContent, 30*time.Millisecond)
+	h.Observe("", http.MethodGet, http.StatusNotFound, time.Millisecond)
+
+	want := `
+# HELP http_requests_total Total number of HTTP requests by operation, method and status class.
+# TYPE http_requests_total counter
+http_requests_total{method="GET",operation_id="ListProducts",status_class="2xx"} 2
+http_requests_total{method="GET",operation_id="unmatched",status_class="4xx"} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(want), "http_requests_total"); err != nil {
+		t.Fatal(err)
+	}
+	if n := testutil.CollectAndCount(registry, "http_request_duration_seconds"); n != 2 {
+		t.Fatalf("expected 2 latency series, got %d", n)
+	}
+
+	h.ValidationFailures().WithLabelValues("ListProducts").Inc()
+	if n := testutil.CollectAndCount(registry, "response_validation_failures_total"); n != 1 {
+		t.Fatalf("expected 1 response validation series, got %d", n)
+	}
+
+	if _, err := metrics.NewHTTP(registry); err == nil {
+		t.Fatal("expected registering the metrics twice to fail")
+	}
+}
+
+func TestStatusClass(t *testing.T) {
+	for status, want := range map[int]string{200: "2xx", 204: "2xx", 404: "4xx", 504: "5xx", 0: "unknown"} {
+		if got := metrics.StatusClass(status); got != want {
+			t.Errorf("StatusClass(%d) = %q, want %q", status, got, want)
+		}
+	}
+}
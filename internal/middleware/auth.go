@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"oapi-codegen-layout/internal/apierror"
+	"oapi-codegen-layout/internal/apispec"
+	"oapi-codegen-layout/internal/auth"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+// credentials are the verified claims a request presented per kind of credential
+type credentials struct {
+	bearer *auth.Claims
+	apiKey *auth.Claims
+}
+
+// Authenticate returns a middleware that enforces the security requirements
+// of the OpenAPI operation of each route. Bearer tokens are verified with
+// verifier and API keys with apiKeys; either must grant the scopes the
+// operation lists for its scheme. Without verifier bearer tokens are rejected. The claims of the caller are stored with
+// auth.SetClaims. Operations without requirements are public.
+func Authenticate(index *apispec.Index, verifier *auth.Verifier, apiKeys *auth.APIKeys) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		op, ok := index.Match(c)
+		if !ok {
+			c.Next()
+			return
+		}
+		requirements := op.Security()
+		if len(requirements) == 0 {
+			c.Next()
+			return
+		}
+
+		var creds credentials
+		var err error
+		if verifier == nil && c.GetHeader("Authorization") != "" {
+			unauthorized(c, "Bearer tokens are not accepted; authenticate with an API key")
+			return
+		}
+		if creds.bearer, err = bearerClaims(c, verifier); err != nil {
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			unauthorized(c, "The bearer token is invalid or has expired")
+			return
+		}
+		if creds.apiKey, err = apiKeyClaims(c, apiKeys); err != nil {
+			if !errors.Is(err, auth.ErrInvalidAPIKey) {
+				slog.ErrorContext(c.Request.Context(), "Failed to verify API key", slog.Any("error", err))
+			}
+			unauthorized(c, "The API key is invalid, expired or revoked")
+			return
+		}
+
+		switch {
+		case creds.bearer != nil:
+			auth.SetClaims(c, creds.bearer)
+		case creds.apiKey != nil:
+			auth.SetClaims(c, creds.apiKey)
+		}
+
+		var missing []string
+		for _, requirement := range requirements {
+			scopes, satisfied := satisfies(op, requirement, creds)
+			if satisfied {
+				c.Next()
+				return
+			}
+			for _, scope := range scopes {
+				if !slices.Contains(missing, scope) {
+					missing = append(missing, scope)
+				}
+			}
+		}
+
+		if creds.bearer == nil && creds.apiKey == nil {
+			c.Header("WWW-Authenticate", "Bearer")
+			unauthorized(c, "A bearer token or API key is required")
+			return
+		}
+		if creds.bearer != nil {
+			c.Header("WWW-Authenticate", fmt.Sprintf(`Bearer error="insufficient_scope", scope="%s"`, strings.Join(missing, " ")))
+		}
+		apierror.Abort(c, http.StatusForbidden, apimodels.Error{
+			Code:    "insufficient_scope",
+			Message: fmt.Sprintf("The credentials lack the required scope: %s", strings.Join(missing, " ")),
+		})
+	}
+}
+
+// unauthorized rejects a request whose credentials are missing or invalid
+func unauthorized(c *gin.Context, message string) {
+	apierror.Abort(c, http.StatusUnauthorized, apimodels.Error{
+		Code:    "unauthorized",
+		Message: message,
+	})
+}
+
+// bearerClaims verifies the bearer token of the request, if there is one
+func bearerClaims(c *gin.Context, verifier *auth.Verifier) (*auth.Claims, error) {
+	header := c.GetHeader("Authorization")
+	if header == "" {
+		return nil, nil
+	}
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return nil, auth.ErrInvalidToken
+	}
+	return verifier.Verify(strings.TrimSpace(token))
+}
+
+// apiKeyClaims verifies the API key of the request, if there is one
+func apiKeyClaims(c *gin.Context, apiKeys *auth.APIKeys) (*auth.Claims, error) {
+	key := c.GetHeader(auth.APIKeyHeader)
+	if key == "" || apiKeys == nil {
+		return nil, nil
+	}
+	return apiKeys.Verify(c.Request.Context(), key)
+}
+
+// satisfies reports whether the caller meets every scheme of a security
+// requirement. It also returns the scopes the requirement asks for.
+func satisfies(op *apispec.Operation, requirement map[string][]string, creds credentials) ([]string, bool) {
+	var scopes []string
+	satisfied := true
+	for name, required := range requirement {
+		scopes = append(scopes, required...)
+
+		var claims *auth.Claims
+		if scheme, ok := op.SecurityScheme(name); ok {
+			switch {
+			case isBearer(scheme):
+				claims = creds.bearer
+			case isAPIKey(scheme):
+				claims = creds.apiKey
+			}
+		}
+		if claims == nil || !claims.HasScopes(required...) {
+			satisfied = false
+		}
+	}
+	return scopes, satisfied
+}
+
+// isBearer reports whether a security scheme is HTTP bearer authentication
+func isBearer(scheme *openapi3.SecurityScheme) bool {
+	return scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "bearer")
+}
+
+// isAPIKey reports whether a security scheme is the API key header
+func isAPIKey(scheme *openapi3.SecurityScheme) bool {
+	return scheme.Type == "apiKey" && scheme.In == "header" && strings.EqualFold(scheme.Name, auth.APIKeyHeader)
+}
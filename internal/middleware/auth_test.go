@@ -0,0 +1,75 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"oapi-codegen-layout/internal/auth"
+	"oapi-codegen-layout/internal/config"
+	"oapi-codegen-layout/internal/models"
+	"oapi-codegen-layout/internal/tenant"
+	"oapi-codegen-layout/internal/testutil"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+func TestAuthenticate(t *testing.T) {
+	s := testutil.NewServer(t)
+	product := apimodels.CreateProductRequest{Name: "Widget", Price: 9.99, Category: "tools"}
+
+	s.Token = ""
+	testutil.ExpectError(t, s.Do(t, http.MethodGet, "/products", nil), http.StatusUnauthorized, "unauthorized")
+
+	s.Token = "not-a-jwt"
+	resp := s.Do(t, http.MethodGet, "/products", nil)
+	testutil.ExpectError(t, resp, http.StatusUnauthorized, "unauthorized")
+	if got := resp.Header.Get("WWW-Authenticate"); got != `Bearer error="invalid_token"` {
+		t.Fatalf("unexpected WWW-Authenticate header %q", got)
+	}
+
+	s.Token = testutil.NewToken(t, "wrong-secret", s.Admin.ID.String(), "products:read")
+	testutil.ExpectError(t, s.Do(t, http.MethodGet, "/products", nil), http.StatusUnauthorized, "unauthorized")
+
+	s.Token = testutil.NewToken(t, testutil.JWTSecret, s.Admin.ID.String(), "products:read")
+	testutil.Expect[[]apimodels.Product](t, s.Do(t, http.MethodGet, "/products", nil), http.StatusOK)
+	resp = s.Do(t, http.MethodPost, "/products", product)
+	testutil.ExpectError(t, resp, http.StatusForbidden, "insufficient_scope")
+	if got := resp.Header.Get("WWW-Authenticate"); got != `Bearer error="insufficient_scope", scope="products:write"` {
+		t.Fatalf("unexpected WWW-Authenticate header %q", got)
+	}
+
+	s.Token = testutil.NewToken(t, testutil.JWTSecret, s.Admin.ID.String(), "products:read", "products:write")
+	testutil.Expect[apimodels.Product](t, s.Do(t, http.MethodPost, "/products", product), http.StatusCreated)
+}
+
+func TestAuthenticatePublicOperations(t *testing.T) {
+	s := testutil.NewServer(t)
+	s.Token = ""
+
+	testutil.Expect[apimodels.HealthResponse](t, s.Do(t, http.MethodGet, "/health", nil), http.StatusOK)
+}
+
+func TestAuthenticateWithAPIKeysOnly(t *testing.T) {
+	s := testutil.NewServer(t, testutil.WithAuthConfig(config.AuthConfig{APIKeys: true}))
+
+	key, prefix, secretHash, err := auth.GenerateAPIKey()
+	if err != nil {
+		t.Fatalf("GenerateAPIKey: %v", err)
+	}
+	ctx := tenant.WithID(context.Background(), tenant.Default)
+	if err := s.Deps.APIKeys.Create(ctx, &models.APIKey{
+		Name: "billing", Prefix: prefix, SecretHash: secretHash, Scopes: "products:read",
+	}); err != nil {
+		t.Fatalf("failed to create API key: %v", err)
+	}
+
+	testutil.ExpectError(t, s.Do(t, http.MethodGet, "/products", nil), http.StatusUnauthorized, "unauthorized")
+
+	req := s.NewRequest(t, http.MethodGet, "/products", nil)
+	req.Header.Set("X-API-Key", key)
+	testutil.Expect[[]apimodels.Product](t, s.Serve(req), http.StatusOK)
+
+	// Without a verifier bearer tokens are rejected, even next to a valid key
+	req.Header.Set("Authorization", "Bearer "+testutil.NewToken(t, testutil.JWTSecret, "someone", "products:read"))
+	testutil.ExpectError(t, s.Serve(req), http.StatusUnauthorized, "unauthorized")
+}
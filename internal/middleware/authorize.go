@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"oapi-codegen-layout/internal/apierror"
+	"oapi-codegen-layout/internal/apispec"
+	"oapi-codegen-layout/internal/auth"
+	"oapi-codegen-layout/internal/authz"
+	"oapi-codegen-layout/internal/repository"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+// Authorize returns a middleware that enforces policy for the operation of
+// each route. The caller's role is read from the user named by the subject
+// of the authenticated claims and stored with authz.SetRole. API keys are
+// limited by their scopes only, and requests without claims are left to
+// Authenticate. It runs inside the generated wrapper and must not call c.Next.
+func Authorize(index *apispec.Index, policy authz.Policy, users repository.UserRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		op, ok := index.Match(c)
+		if !ok {
+			return
+		}
+		required, ok := policy.Required(op.ID())
+		if !ok {
+			return
+		}
+		claims, ok := auth.ClaimsFromContext(c)
+		if !ok || claims.IsAPIKey() {
+			return
+		}
+
+		role, err := userRole(c, users, claims.Subject)
+		if err != nil && apierror.DeadlineExceeded(c, err) {
+			apierror.RespondTimeout(c)
+			c.Abort()
+			return
+		}
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			apierror.Abort(c, http.StatusInternalServerError, apimodels.Error{
+				Code:    "database_error",
+				Message: "Failed to resolve the caller's role",
+			})
+			return
+		}
+		if err != nil || !role.Includes(required) {
+			apierror.Abort(c, http.StatusForbidden, apimodels.Error{
+				Code:    "forbidden",
+				Message: fmt.Sprintf("This operation requires the %s role", required),
+			})
+			return
+		}
+		authz.SetRole(c, role)
+	}
+}
+
+// userRole returns the role of the user with the given ID. Subjects that do
+// not name a user are reported as repository.ErrNotFound.
+func userRole(c *gin.Context, users repository.UserRepository, subject string) (authz.Role, error) {
+	id, err := uuid.Parse(subject)
+	if err != nil {
+		return "", repository.ErrNotFound
+	}
+	user, err := users.Get(c.Request.Context(), id)
+	if err != nil {
+		return "", err
+	}
+	role, ok := authz.ParseRole(user.Role)
+	if !ok {
+		return "", repository.ErrNotFound
+	}
+	return role, nil
+}
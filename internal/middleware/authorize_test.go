@@ -0,0 +1,80 @@
+package middleware_test
+
+import (
+	"net/http"
+	"testing"
+
+	"oapi-codegen-layout/internal/testutil"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+func TestAuthorize(t *testing.T) {
+	s := testutil.NewServer(t)
+	product := apimodels.CreateProductRequest{Name: "Widget", Price: 9.99, Category: "tools"}
+	editorRole := apimodels.CreateUserRequestRoleEditor
+
+	viewer := s.CreateUser(t, apimodels.CreateUserRequest{Email: "viewer@example.com", Name: "Viewer"})
+	if viewer.Role != apimodels.UserRoleViewer {
+		t.Fatalf("expected new users to default to viewer, got %q", viewer.Role)
+	}
+	editor := s.CreateUser(t, apimodels.CreateUserRequest{Email: "editor@example.com", Name: "Editor", Role: &editorRole})
+	scopes := []string{"users:read", "users:write", "products:read", "products:write"}
+
+	s.Token = testutil.NewToken(t, testutil.JWTSecret, viewer.Id.String(), scopes...)
+	testutil.Expect[[]apimodels.Product](t, s.Do(t, http.MethodGet, "/products", nil), http.StatusOK)
+	apiErr := testutil.ExpectError(t, s.Do(t, http.MethodPost, "/products", product), http.StatusForbidden, "forbidden")
+	if apiErr.Message != "This operation requires the editor role" {
+		t.Fatalf("unexpected message %q", apiErr.Message)
+	}
+	testutil.ExpectError(t, s.Do(t, http.MethodDelete, "/users/"+editor.Id.String(), nil), http.StatusForbidden, "forbidden")
+
+	s.Token = testutil.NewToken(t, testutil.JWTSecret, editor.Id.String(), scopes...)
+	testutil.Expect[apimodels.Product](t, s.Do(t, http.MethodPost, "/products", product), http.StatusCreated)
+	testutil.ExpectError(t, s.Do(t, http.MethodDelete, "/users/"+viewer.Id.String(), nil), http.StatusForbidden, "forbidden")
+
+	// Tokens for subjects that are not users hold no role
+	s.Token = testutil.NewToken(t, testutil.JWTSecret, "someone-else", scopes...)
+	testutil.ExpectError(t, s.Do(t, http.MethodGet, "/products", nil), http.StatusForbidden, "forbidden")
+}
+
+func TestAuthorizeRoleChange(t *testing.T) {
+	s := testutil.NewServer(t)
+	adminToken := s.Token
+	user := s.CreateUser(t, apimodels.CreateUserRequest{Email: "user@example.com", Name: "User"})
+	product := apimodels.CreateProductRequest{Name: "Widget", Price: 9.99, Category: "tools"}
+
+	s.Token = testutil.NewToken(t, testutil.JWTSecret, user.Id.String(), "products:read", "products:write")
+	testutil.ExpectError(t, s.Do(t, http.MethodPost, "/products", product), http.StatusForbidden, "forbidden")
+
+	s.Token = adminToken
+	editorRole := apimodels.UpdateUserRequestRoleEditor
+	updated := testutil.Expect[apimodels.User](t,
+		s.Do(t, http.MethodPut, "/users/"+user.Id.String(), apimodels.UpdateUserRequest{Role: &editorRole}),
+		http.StatusOK)
+	if updated.Role != apimodels.UserRoleEditor {
+		t.Fatalf("expected role editor, got %q", updated.Role)
+	}
+
+	s.Token = testutil.NewToken(t, testutil.JWTSecret, user.Id.String(), "products:read", "products:write")
+	testutil.Expect[apimodels.Product](t, s.Do(t, http.MethodPost, "/products", product), http.StatusCreated)
+}
+
+func TestAuthorizeSkipsAPIKeys(t *testing.T) {
+	s := testutil.NewServer(t)
+	key := s.CreateAPIKey(t, apimodels.CreateAPIKeyRequest{Name: "ci", Scopes: []string{"products:write"}})
+	product := apimodels.CreateProductRequest{Name: "Widget", Price: 9.99, Category: "tools"}
+
+	s.Token = ""
+	req := s.NewRequest(t, http.MethodPost, "/products", product)
+	req.Header.Set("X-API-Key", key.Key)
+	testutil.Expect[apimodels.Product](t, s.Serve(req), http.StatusCreated)
+}
+
+func TestAuthorizeChecksForgedAPIKeySubjects(t *testing.T) {
+	s := testutil.NewServer(t)
+	user := s.CreateUser(t, apimodels.CreateUserRequest{Email: "user@example.com", Name: "User"})
+
+	// A bearer token whose subject looks like an API key is still role checked
+	s.Token = testutil.NewToken(t, testutil.JWTSecret, "api-key:forged", "users:read", "users:write")
+	testutil.ExpectError(t, s.Do(t, http.MethodDelete, "/users/"+user.Id.String(), nil), http.StatusForbidden, "forbidden")
+}
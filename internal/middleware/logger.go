@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"oapi-codegen-layout/internal/apierror"
+	"oapi-codegen-layout/internal/apispec"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+// Logger returns a middleware that logs one structured record per request
+// with its route, operationId, status and latency. Server errors are logged
+// at error level and client errors at warn level. It must run after RequestID
+// so the records carry the request ID.
+func Logger(index *apispec.Index, logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("route", c.FullPath()),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.Int("bytes", max(c.Writer.Size(), 0)),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if op, ok := index.Match(c); ok {
+			attrs = append(attrs, slog.String("operation_id", op.ID()))
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		logger.LogAttrs(c.Request.Context(), level, "Request handled", attrs...)
+	}
+}
+
+// Recovery returns a middleware that turns panics into 500 responses and logs
+// them with their stack trace
+func Recovery(logger *slog.Logger) gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, err any) {
+		logger.ErrorContext(c.Request.Context(), "Panic while handling request",
+			slog.Any("error", err),
+			slog.String("stack", string(debug.Stack())))
+		apierror.Abort(c, http.StatusInternalServerError, apimodels.Error{
+			Code:    "internal_error",
+			Message: "Failed to process the request",
+		})
+	})
+}
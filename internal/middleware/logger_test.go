@@ -0,0 +1,57 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"oapi-codegen-layout/internal/config"
+	"oapi-codegen-layout/internal/logging"
+	"oapi-codegen-layout/internal/middleware"
+	"oapi-codegen-layout/internal/testutil"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+func TestRequestID(t *testing.T) {
+	s := testutil.NewServer(t)
+
+	req := s.NewRequest(t, http.MethodGet, "/health", nil)
+	req.Header.Set(middleware.RequestIDHeader, "client-id-42")
+	if got := s.Serve(req).Header.Get(middleware.RequestIDHeader); got != "client-id-42" {
+		t.Fatalf("expected the client request ID to be kept, got %q", got)
+	}
+
+	req = s.NewRequest(t, http.MethodGet, "/health", nil)
+	req.Header.Set(middleware.RequestIDHeader, "not a valid id\n")
+	if got := s.Serve(req).Header.Get(middleware.RequestIDHeader); got == "" || got == "not a valid id\n" {
+		t.Fatalf("expected a generated request ID, got %q", got)
+	}
+}
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(config.LogConfig{Level: "info", Format: logging.FormatJSON}, &buf)
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	s := testutil.NewServer(t, testutil.WithLogger(logger))
+
+	req := s.NewRequest(t, http.MethodPost, "/products", apimodels.CreateProductRequest{Name: "Widget", Price: 9.99, Category: "tools"})
+	req.Header.Set(middleware.RequestIDHeader, "req-7")
+	if r := s.Serve(req); r.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", r.Code, r.Body)
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected one JSON record per request, got %q: %v", buf.String(), err)
+	}
+	if record["operation_id"] != "CreateProduct" || record["status"] != float64(http.StatusCreated) ||
+		record["route"] != testutil.BasePath+"/products" || record[logging.RequestIDKey] != "req-7" {
+		t.Fatalf("unexpected record %v", record)
+	}
+	if _, ok := record["latency"]; !ok {
+		t.Fatalf("expected the latency to be logged, got %v", record)
+	}
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"oapi-codegen-layout/internal/apispec"
+	"oapi-codegen-layout/internal/metrics"
+)
+
+// Metrics returns a middleware that records the count and latency of every
+// request, labelled by the operationId of its route and its status class
+func Metrics(index *apispec.Index, m *metrics.HTTP) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		var operationID string
+		if op, ok := index.Match(c); ok {
+			operationID = op.ID()
+		}
+		m.Observe(operationID, c.Request.Method, c.Writer.Status(), time.Since(start))
+	}
+}
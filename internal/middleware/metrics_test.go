@@ -0,0 +1,36 @@
+package middleware_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"oapi-codegen-layout/internal/testutil"
+)
+
+func TestMetrics(t *testing.T) {
+	s := testutil.NewServer(t, testutil.WithMetrics())
+
+	testutil.Expect[any](t, s.Do(t, http.MethodGet, "/products", nil), http.StatusOK)
+	testutil.ExpectError(t, s.Do(t, http.MethodGet, "/products/not-a-uuid", nil), http.StatusBadRequest, "invalid_parameter")
+	s.Do(t, http.MethodGet, "/no-such-path", nil)
+
+	req := s.NewRequest(t, http.MethodGet, "/metrics", nil)
+	req.URL.Path = "/metrics"
+	r := s.Serve(req)
+	if r.Code != http.StatusOK {
+		t.Fatalf("expected 200 from /metrics, got %d", r.Code)
+	}
+	body := string(r.Body)
+	for _, want := range []string{
+		`http_requests_total{method="GET",operation_id="ListProducts",status_class="2xx"} 1`,
+		`http_requests_total{method="GET",operation_id="GetProductById",status_class="4xx"} 1`,
+		`http_requests_total{method="GET",operation_id="unmatched",status_class="4xx"} 1`,
+		`http_request_duration_seconds_count{method="GET",operation_id="ListProducts"} 1`,
+		`go_goroutines `,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %s in:\n%s", want, body)
+		}
+	}
+}
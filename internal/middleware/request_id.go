@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"oapi-codegen-layout/internal/logging"
+)
+
+// RequestIDHeader is the header that carries the ID of a request
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDPattern restricts client-supplied request IDs to short tokens that
+// are safe to log and echo back
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID returns a middleware that assigns every request an ID. A valid
+// X-Request-ID sent by the client is kept, otherwise a UUID is generated. The
+// ID is returned in the X-Request-ID response header and stored in the
+// request context, where logging picks it up.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !requestIDPattern.MatchString(id) {
+			id = uuid.NewString()
+		}
+
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), id))
+		c.Next()
+	}
+}
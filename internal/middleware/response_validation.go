@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"oapi-codegen-layout/internal/apierror"
+	"oapi-codegen-layout/internal/apispec"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+// ValidateResponses returns a middleware that buffers each response and
+// validates its status, headers and body against the OpenAPI operation of
+// its route. Mismatches are counted in failures by operationId when it is not
+// nil. In debug and test mode a mismatch is logged as error and replaced by a
+// 500; in release mode the original response is sent and the mismatch is
+// logged as warning.
+func ValidateResponses(index *apispec.Index, mode string, failures *prometheus.CounterVec) gin.HandlerFunc {
+	strict := mode != gin.ReleaseMode
+	options := &openapi3filter.Options{
+		// Status codes that are not documented are mismatches too
+		IncludeResponseStatus: true,
+	}
+
+	return func(c *gin.Context) {
+		op, ok := index.Match(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		writer := &bufferedWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		input := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: &openapi3filter.RequestValidationInput{
+				Request: c.Request,
+				Route:   op.Route(),
+			},
+			Status:  writer.status,
+			Header:  writer.Header(),
+			Body:    io.NopCloser(bytes.NewReader(writer.body.Bytes())),
+			Options: options,
+		}
+		err := openapi3filter.ValidateResponse(c.Request.Context(), input)
+		if err == nil {
+			writer.flush()
+			return
+		}
+
+		if failures != nil {
+			failures.WithLabelValues(op.ID()).Inc()
+		}
+		if !strict {
+			slog.WarnContext(c.Request.Context(), "Response does not match the API specification",
+				slog.String("operation_id", op.ID()), slog.Any("error", err))
+			writer.flush()
+			return
+		}
+
+		slog.ErrorContext(c.Request.Context(), "Response does not match the API specification",
+			slog.String("operation_id", op.ID()), slog.Any("error", err))
+		c.Writer.Header().Del("Content-Type")
+		apierror.Respond(c, http.StatusInternalServerError, apimodels.Error{
+			Code:    "invalid_response",
+			Message: "Response does not match the API specification",
+		})
+	}
+}
+
+// bufferedWriter holds back the status and body written by the handlers
+// until the response has been validated
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status  int
+	written bool
+	body    bytes.Buffer
+}
+
+// WriteHeader records the status code without sending it
+func (w *bufferedWriter) WriteHeader(code int) {
+	if code > 0 && !w.written {
+		w.status = code
+	}
+}
+
+// WriteHeaderNow marks the header as written without sending it
+func (w *bufferedWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+// Write buffers the body
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	w.written = true
+	return w.body.Write(data)
+}
+
+// WriteString buffers the body
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	w.written = true
+	return w.body.WriteString(s)
+}
+
+// Status returns the buffered status code
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+// Size returns the number of buffered body bytes, or -1 before anything was written
+func (w *bufferedWriter) Size() int {
+	if !w.written {
+		return -1
+	}
+	return w.body.Len()
+}
+
+// Written reports whether the handlers have written a response
+func (w *bufferedWriter) Written() bool {
+	return w.written
+}
+
+// Flush is a no-op; buffered responses are sent as a whole
+func (w *bufferedWriter) Flush() {}
+
+// flush sends the buffered response to the underlying writer
+func (w *bufferedWriter) flush() {
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.body.Len() > 0 {
+		w.ResponseWriter.Write(w.body.Bytes())
+	} else {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
@@ -0,0 +1,86 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"oapi-codegen-layout/internal/apispec"
+	"oapi-codegen-layout/internal/middleware"
+	"oapi-codegen-layout/pkg/api/users"
+)
+
+// newResponseValidationEngine serves GET /users/:userId with the given handler
+// behind the response validation middleware, counting mismatches in failures
+func newResponseValidationEngine(t *testing.T, mode string, handler gin.HandlerFunc, failures *prometheus.CounterVec) *gin.Engine {
+	t.Helper()
+
+	spec, err := users.GetSwagger()
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	index := apispec.NewIndex("/api/v1", spec)
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	group := engine.Group("/api/v1")
+	group.Use(middleware.ValidateResponses(index, mode, failures))
+	group.GET("/users/:userId", handler)
+	return engine
+}
+
+func TestValidateResponses(t *testing.T) {
+	valid := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"id":        uuid.NewString(),
+			"email":     "a@example.com",
+			"name":      "A",
+			"role":      "viewer",
+			"createdAt": "2024-01-01T00:00:00Z",
+		})
+	}
+	missingField := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"id": uuid.NewString(), "name": "A"})
+	}
+	undocumentedStatus := func(c *gin.Context) {
+		c.JSON(http.StatusTeapot, gin.H{"code": "teapot", "message": "I'm a teapot"})
+	}
+
+	tests := []struct {
+		name    string
+		mode    string
+		handler gin.HandlerFunc
+		status  int
+		failed  bool
+	}{
+		{"valid response", gin.DebugMode, valid, http.StatusOK, false},
+		{"missing required field in debug mode", gin.DebugMode, missingField, http.StatusInternalServerError, true},
+		{"undocumented status in test mode", gin.TestMode, undocumentedStatus, http.StatusInternalServerError, true},
+		{"missing required field in release mode", gin.ReleaseMode, missingField, http.StatusOK, true},
+		{"undocumented status in release mode", gin.ReleaseMode, undocumentedStatus, http.StatusTeapot, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failures := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "failures"}, []string{"operation_id"})
+			engine := newResponseValidationEngine(t, tt.mode, tt.handler, failures)
+
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/users/"+uuid.NewString(), nil))
+			if rec.Code != tt.status {
+				t.Fatalf("expected status %d, got %d: %s", tt.status, rec.Code, rec.Body)
+			}
+			want := 0.0
+			if tt.failed {
+				want = 1
+			}
+			if got := testutil.ToFloat64(failures.WithLabelValues("GetUserById")); got != want {
+				t.Fatalf("expected %v counted failures, got %v", want, got)
+			}
+		})
+	}
+}
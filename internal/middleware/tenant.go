@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"oapi-codegen-layout/internal/apierror"
+	"oapi-codegen-layout/internal/auth"
+	"oapi-codegen-layout/internal/tenant"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+// ResolveTenant returns a middleware that determines the tenant of each
+// request and restricts the request context to it with tenant.WithID.
+// Authenticated callers belong to the tenant of their claims, or the default
+// tenant when the claim is absent, and cannot select another one with the
+// X-Tenant-ID header. Anonymous callers select their tenant with the header.
+// It must run after Authenticate.
+func ResolveTenant() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requested := c.GetHeader(tenant.Header)
+		if requested != "" && !tenant.ValidID(requested) {
+			field := tenant.Header
+			apierror.Abort(c, http.StatusBadRequest, apimodels.Error{
+				Code:    "invalid_tenant",
+				Message: "Tenant ID must be a lower-case slug of at most 64 characters",
+				Field:   &field,
+			})
+			return
+		}
+
+		id := tenant.Default
+		if claims, ok := auth.ClaimsFromContext(c); ok {
+			if claims.Tenant != "" {
+				id = claims.Tenant
+			}
+			if requested != "" && requested != id {
+				apierror.Abort(c, http.StatusForbidden, apimodels.Error{
+					Code:    "tenant_mismatch",
+					Message: "Credentials do not belong to the requested tenant",
+				})
+				return
+			}
+		} else if requested != "" {
+			id = requested
+		}
+
+		c.Request = c.Request.WithContext(tenant.WithID(c.Request.Context(), id))
+		c.Next()
+	}
+}
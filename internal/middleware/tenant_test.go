@@ -0,0 +1,69 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"oapi-codegen-layout/internal/authz"
+	"oapi-codegen-layout/internal/config"
+	"oapi-codegen-layout/internal/models"
+	"oapi-codegen-layout/internal/tenant"
+	"oapi-codegen-layout/internal/testutil"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+// doAs sends a request on behalf of the tenant named in the X-Tenant-ID header
+func doAs(t *testing.T, s *testutil.Server, tenantID, method, path string, body any) *testutil.Response {
+	t.Helper()
+	req := s.NewRequest(t, method, path, body)
+	req.Header.Set(tenant.Header, tenantID)
+	return s.Serve(req)
+}
+
+func TestResolveTenantIsolatesAnonymousCallers(t *testing.T) {
+	for name, opts := range map[string][]testutil.Option{
+		"memory": nil,
+		"sqlite": {testutil.WithSQLite()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := testutil.NewServer(t, append(opts, testutil.WithAuthConfig(config.AuthConfig{}))...)
+			user := apimodels.CreateUserRequest{Email: "jane@example.com", Name: "Jane"}
+
+			acme := testutil.Expect[apimodels.User](t, doAs(t, s, "acme", http.MethodPost, "/users", user), http.StatusCreated)
+			// Email addresses are only unique within a tenant
+			testutil.Expect[apimodels.User](t, doAs(t, s, "globex", http.MethodPost, "/users", user), http.StatusCreated)
+			testutil.ExpectError(t, doAs(t, s, "acme", http.MethodPost, "/users", user), http.StatusConflict, "email_taken")
+
+			testutil.Expect[apimodels.User](t, doAs(t, s, "acme", http.MethodGet, "/users/"+acme.Id.String(), nil), http.StatusOK)
+			testutil.ExpectError(t, doAs(t, s, "globex", http.MethodGet, "/users/"+acme.Id.String(), nil), http.StatusNotFound, "not_found")
+			testutil.ExpectError(t, doAs(t, s, "globex", http.MethodDelete, "/users/"+acme.Id.String(), nil), http.StatusNotFound, "not_found")
+
+			list := testutil.Expect[[]apimodels.User](t, s.Do(t, http.MethodGet, "/users", nil), http.StatusOK)
+			if len(list) != 0 {
+				t.Fatalf("expected the default tenant to be empty, got %d users", len(list))
+			}
+
+			testutil.ExpectError(t, doAs(t, s, "Not A Tenant", http.MethodGet, "/users", nil), http.StatusBadRequest, "invalid_tenant")
+		})
+	}
+}
+
+func TestResolveTenantFromClaims(t *testing.T) {
+	s := testutil.NewServer(t)
+	admin := &models.User{Email: "admin@acme.example", Name: "Acme Admin", Role: string(authz.RoleAdmin)}
+	if err := s.Deps.Users.Create(tenant.WithID(context.Background(), "acme"), admin); err != nil {
+		t.Fatalf("failed to create admin: %v", err)
+	}
+	defaultUser := s.CreateUser(t, apimodels.CreateUserRequest{Email: "jane@example.com", Name: "Jane"})
+
+	s.Token = testutil.NewTenantToken(t, testutil.JWTSecret, "acme", admin.ID.String(), "users:read")
+	list := testutil.Expect[[]apimodels.User](t, s.Do(t, http.MethodGet, "/users", nil), http.StatusOK)
+	if len(list) != 1 || list[0].Id != admin.ID {
+		t.Fatalf("expected only the acme admin, got %+v", list)
+	}
+	testutil.ExpectError(t, s.Do(t, http.MethodGet, "/users/"+defaultUser.Id.String(), nil), http.StatusNotFound, "not_found")
+
+	testutil.Expect[[]apimodels.User](t, doAs(t, s, "acme", http.MethodGet, "/users", nil), http.StatusOK)
+	testutil.ExpectError(t, doAs(t, s, tenant.Default, http.MethodGet, "/users", nil), http.StatusForbidden, "tenant_mismatch")
+}
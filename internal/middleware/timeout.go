@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"oapi-codegen-layout/internal/apierror"
+	"oapi-codegen-layout/internal/apispec"
+)
+
+// Timeout returns a middleware that gives every request a deadline: the
+// x-timeout of its operation, or defaultTimeout. A zero defaultTimeout leaves
+// operations without x-timeout unbounded. Database calls made with the
+// request context are cancelled when the deadline passes; if the handler
+// has not responded by then, the request fails with 504 timeout. Invalid
+// x-timeout values are reported when the middleware is created.
+func Timeout(index *apispec.Index, defaultTimeout time.Duration) (gin.HandlerFunc, error) {
+	timeouts := make(map[*apispec.Operation]time.Duration)
+	for _, op := range index.Operations() {
+		timeout, ok, err := op.Timeout()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			timeouts[op] = timeout
+		}
+	}
+
+	return func(c *gin.Context) {
+		timeout := defaultTimeout
+		if op, ok := index.Match(c); ok {
+			if t, ok := timeouts[op]; ok {
+				timeout = t
+			}
+		}
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		if !c.Writer.Written() && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			apierror.RespondTimeout(c)
+		}
+	}, nil
+}
@@ -0,0 +1,97 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"oapi-codegen-layout/internal/apispec"
+	"oapi-codegen-layout/internal/config"
+	"oapi-codegen-layout/internal/middleware"
+	"oapi-codegen-layout/internal/testutil"
+)
+
+// timeoutSpec builds an index of a spec with a fast and a slow operation
+func timeoutSpec(t *testing.T, fastTimeout string) *apispec.Index {
+	t.Helper()
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: 3.0.3
+info: {title: Timeouts, version: 1.0.0}
+paths:
+  /fast:
+    get:
+      operationId: fast
+      x-timeout: ` + fastTimeout + `
+      responses: {"200": {description: OK}}
+  /slow:
+    get:
+      operationId: slow
+      responses: {"200": {description: OK}}
+`))
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	return apispec.NewIndex("", spec)
+}
+
+func TestTimeout(t *testing.T) {
+	timeout, err := middleware.Timeout(timeoutSpec(t, "20ms"), time.Hour)
+	if err != nil {
+		t.Fatalf("Timeout: %v", err)
+	}
+
+	deadlines := map[string]time.Duration{}
+	engine := gin.New()
+	engine.Use(timeout)
+	waitForDeadline := func(c *gin.Context) {
+		deadline, _ := c.Request.Context().Deadline()
+		deadlines[c.FullPath()] = time.Until(deadline)
+		if c.FullPath() == "/fast" {
+			<-c.Request.Context().Done()
+		}
+	}
+	engine.GET("/fast", waitForDeadline)
+	engine.GET("/slow", waitForDeadline)
+
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/fast", nil))
+	if rec.Code != http.StatusGatewayTimeout || !strings.Contains(rec.Body.String(), `"timeout"`) {
+		t.Fatalf("expected 504 timeout, got %d: %s", rec.Code, rec.Body)
+	}
+
+	rec = httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if deadlines["/fast"] > 20*time.Millisecond || deadlines["/slow"] < time.Minute {
+		t.Fatalf("expected x-timeout to override the default, got %v", deadlines)
+	}
+}
+
+func TestTimeoutRejectsInvalidExtension(t *testing.T) {
+	if _, err := middleware.Timeout(timeoutSpec(t, "soon"), time.Second); err == nil {
+		t.Fatal("expected an invalid x-timeout to be rejected")
+	}
+}
+
+func TestTimeoutCancelsDatabaseCalls(t *testing.T) {
+	s := testutil.NewServer(t, testutil.WithSQLite(),
+		testutil.WithAuthConfig(config.AuthConfig{}),
+		testutil.WithServerConfig(config.ServerConfig{
+			Mode:              gin.TestMode,
+			ValidateResponses: true,
+			RequestTimeout:    time.Nanosecond,
+		}))
+
+	// Health does not touch the database; the x-timeout of listProducts
+	// overrides the default
+	testutil.Expect[any](t, s.Do(t, http.MethodGet, "/health", nil), http.StatusOK)
+	testutil.Expect[any](t, s.Do(t, http.MethodGet, "/products", nil), http.StatusOK)
+	testutil.ExpectError(t, s.Do(t, http.MethodGet, "/users/"+uuid.NewString(), nil), http.StatusGatewayTimeout, "timeout")
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"oapi-codegen-layout/internal/apispec"
+	"oapi-codegen-layout/internal/logging"
+	"oapi-codegen-layout/internal/tracing"
+)
+
+// Trace returns a middleware that starts a server span for every request,
+// named by the operationId of its route. The span continues the trace of an
+// incoming W3C traceparent header, and handlers and database queries find it
+// in the request context.
+func Trace(index *apispec.Index) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		// Requests that match no operation are named by their route, or only
+		// by their method for unknown paths
+		name := c.Request.Method
+		attrs := []attribute.KeyValue{
+			semconv.HTTPRequestMethodKey.String(c.Request.Method),
+			semconv.URLPath(c.Request.URL.Path),
+		}
+		if route := c.FullPath(); route != "" {
+			name += " " + route
+			attrs = append(attrs, semconv.HTTPRoute(route))
+		}
+		if op, ok := index.Match(c); ok {
+			name = op.ID()
+			attrs = append(attrs, attribute.String("openapi.operation_id", op.ID()))
+		}
+		if id, ok := logging.RequestID(ctx); ok {
+			attrs = append(attrs, attribute.String("http.request.id", id))
+		}
+
+		ctx, span := tracing.Tracer().Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...))
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
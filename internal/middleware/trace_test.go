@@ -0,0 +1,60 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"oapi-codegen-layout/internal/config"
+	"oapi-codegen-layout/internal/testutil"
+	"oapi-codegen-layout/internal/tracing"
+)
+
+func TestTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	if _, err := tracing.Setup(context.Background(), config.TracingConfig{}); err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+
+	s := testutil.NewServer(t, testutil.WithSQLite())
+	recorder.Reset()
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := s.NewRequest(t, http.MethodGet, "/products", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	if r := s.Serve(req); r.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", r.Code, r.Body)
+	}
+
+	var server sdktrace.ReadOnlySpan
+	queries := 0
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID().String() != traceID {
+			t.Errorf("span %s did not continue the incoming trace", span.Name())
+		}
+		switch span.SpanKind() {
+		case trace.SpanKindServer:
+			server = span
+		case trace.SpanKindClient:
+			queries++
+		}
+	}
+	if server == nil || server.Name() != "ListProducts" {
+		t.Fatalf("expected a ListProducts server span, got %v", server)
+	}
+	attrs := attribute.NewSet(server.Attributes()...)
+	if v, _ := attrs.Value("http.response.status_code"); v.AsInt64() != http.StatusOK {
+		t.Errorf("expected status code 200 on the server span, got %v", v.AsInt64())
+	}
+	if queries == 0 {
+		t.Error("expected the product query to be traced")
+	}
+}
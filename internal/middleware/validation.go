@@ -0,0 +1,126 @@
+// Package middleware holds the Gin middleware shared by all API domains.
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/gin-gonic/gin"
+	"oapi-codegen-layout/internal/apierror"
+	"oapi-codegen-layout/internal/apispec"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+func init() {
+	// kin-openapi leaves these formats unchecked unless they are registered
+	openapi3.DefineStringFormatValidator("email", openapi3.NewRegexpFormatValidator(openapi3.FormatOfStringForEmail))
+	// Accept any hex UUID like the generated parameter binding does, not only RFC 4122 variants
+	openapi3.DefineStringFormatValidator("uuid", openapi3.NewRegexpFormatValidator(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`))
+}
+
+// ValidateRequests returns a middleware that validates the path, query,
+// headers and body of each request against the OpenAPI operation of its
+// route. Invalid requests are rejected with 400 and per-field details.
+func ValidateRequests(index *apispec.Index) gin.HandlerFunc {
+	options := &openapi3filter.Options{
+		MultiError: true,
+		// Authentication is enforced by its own middleware
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(c *gin.Context) {
+		op, ok := index.Match(c)
+		if !ok {
+			return
+		}
+
+		pathParams := make(map[string]string, len(c.Params))
+		for _, param := range c.Params {
+			pathParams[param.Key] = param.Value
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      op.Route(),
+			Options:    options,
+		}
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+			details := validationDetails(err)
+			apierror.Abort(c, http.StatusBadRequest, apimodels.Error{
+				Code:    "validation_failed",
+				Message: "Request does not match the API specification",
+				Details: &details,
+			})
+		}
+	}
+}
+
+// validationDetails flattens a validation error into per-field details
+func validationDetails(err error) []apimodels.ErrorDetail {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var details []apimodels.ErrorDetail
+		for _, inner := range e {
+			details = append(details, validationDetails(inner)...)
+		}
+		return details
+	case *openapi3filter.RequestError:
+		switch {
+		case e.Parameter != nil:
+			var details []apimodels.ErrorDetail
+			for _, reason := range errorReasons(e.Err, e.Reason) {
+				details = append(details, apimodels.ErrorDetail{Field: e.Parameter.Name, Message: reason})
+			}
+			return details
+		case e.RequestBody != nil && e.Err != nil:
+			return bodyDetails(e.Err, e.Reason)
+		}
+		return []apimodels.ErrorDetail{{Field: "request", Message: e.Error()}}
+	}
+	return []apimodels.ErrorDetail{{Field: "request", Message: err.Error()}}
+}
+
+// bodyDetails reports request body schema errors by JSON property path
+func bodyDetails(err error, reason string) []apimodels.ErrorDetail {
+	if multi, ok := err.(openapi3.MultiError); ok {
+		var details []apimodels.ErrorDetail
+		for _, inner := range multi {
+			details = append(details, bodyDetails(inner, reason)...)
+		}
+		return details
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		field := strings.Join(schemaErr.JSONPointer(), ".")
+		if field == "" {
+			field = "body"
+		}
+		return []apimodels.ErrorDetail{{Field: field, Message: schemaErr.Reason}}
+	}
+	return []apimodels.ErrorDetail{{Field: "body", Message: errorReasons(err, reason)[0]}}
+}
+
+// errorReasons prefers the schema violations over the wrapping error message
+func errorReasons(err error, reason string) []string {
+	if multi, ok := err.(openapi3.MultiError); ok {
+		var reasons []string
+		for _, inner := range multi {
+			reasons = append(reasons, errorReasons(inner, reason)...)
+		}
+		return reasons
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		return []string{schemaErr.Reason}
+	}
+	if err != nil {
+		return []string{err.Error()}
+	}
+	return []string{reason}
+}
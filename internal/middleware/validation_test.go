@@ -0,0 +1,57 @@
+package middleware_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"oapi-codegen-layout/internal/testutil"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+func TestValidateRequests(t *testing.T) {
+	s := testutil.NewServer(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   any
+		field  string
+	}{
+		{"name too long", http.MethodPost, "/users", map[string]any{"email": "a@example.com", "name": strings.Repeat("x", 101)}, "name"},
+		{"invalid email", http.MethodPost, "/users", map[string]any{"email": "not-an-email", "name": "A"}, "email"},
+		{"missing email", http.MethodPost, "/users", map[string]any{"name": "A"}, "email"},
+		{"negative price", http.MethodPost, "/products", map[string]any{"name": "Widget", "price": -1}, "price"},
+		{"limit above maximum", http.MethodGet, "/users?limit=500", nil, "limit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiErr := testutil.ExpectError(t, s.Do(t, tt.method, tt.path, tt.body), http.StatusBadRequest, "validation_failed")
+			if !hasDetail(apiErr, tt.field) {
+				t.Fatalf("expected a detail for %q, got %+v", tt.field, apiErr.Details)
+			}
+		})
+	}
+}
+
+func TestValidateRequestsAcceptsValidRequests(t *testing.T) {
+	s := testutil.NewServer(t)
+
+	s.CreateUser(t, apimodels.CreateUserRequest{Email: "valid@example.com", Name: strings.Repeat("x", 100)})
+	testutil.Expect[[]apimodels.User](t, s.Do(t, http.MethodGet, "/users?limit=100", nil), http.StatusOK)
+}
+
+// hasDetail reports whether the error has a detail for field
+func hasDetail(apiErr apimodels.Error, field string) bool {
+	if apiErr.Details == nil {
+		return false
+	}
+	for _, detail := range *apiErr.Details {
+		if detail.Field == field {
+			return true
+		}
+	}
+	return false
+}
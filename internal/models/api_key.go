@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// APIKey is a machine credential. Only a hash of the secret is stored; the
+// prefix identifies the key when a request presents it.
+type APIKey struct {
+	ID         uuid.UUID `gorm:"type:char(36);primaryKey"`
+	TenantID   string    `gorm:"type:varchar(64);not null;default:default;index"`
+	Name       string    `gorm:"type:varchar(100);not null"`
+	Prefix     string    `gorm:"type:varchar(16);uniqueIndex;not null"`
+	SecretHash string    `gorm:"type:char(64);not null"`
+	Scopes     string    `gorm:"type:varchar(1000);not null"` // space-separated
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// BeforeCreate hook to generate UUID before creating
+func (k *APIKey) BeforeCreate(tx *gorm.DB) error {
+	if k.ID == uuid.Nil {
+		k.ID = uuid.New()
+	}
+	return nil
+}
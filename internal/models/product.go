@@ -9,6 +9,7 @@ import (
 
 type Product struct {
 	ID          uuid.UUID `gorm:"type:char(36);primaryKey"`
+	TenantID    string    `gorm:"type:varchar(64);not null;default:default;index"`
 	Name        string    `gorm:"type:varchar(200);not null"`
 	Description *string   `gorm:"type:varchar(1000)"`
 	Price       float64   `gorm:"type:decimal(10,2);not null"`
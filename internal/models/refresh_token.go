@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RefreshToken is a single-use credential that exchanges for a new access
+// token. Tokens issued by rotating one another share a family, so that the
+// whole chain can be revoked on logout or when a used token is replayed.
+// Only a hash of the token is stored.
+type RefreshToken struct {
+	ID        uuid.UUID `gorm:"type:char(36);primaryKey"`
+	TenantID  string    `gorm:"type:varchar(64);not null;default:default;index"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null"`
+	FamilyID  uuid.UUID `gorm:"type:char(36);index;not null"`
+	TokenHash string    `gorm:"type:char(64);uniqueIndex;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// BeforeCreate hook to generate UUID before creating
+func (t *RefreshToken) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
@@ -8,12 +8,15 @@ import (
 )
 
 type User struct {
-	ID        uuid.UUID `gorm:"type:char(36);primaryKey"`
-	Email     string    `gorm:"type:varchar(255);uniqueIndex;not null"`
-	Name      string    `gorm:"type:varchar(100);not null"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID           uuid.UUID `gorm:"type:char(36);primaryKey"`
+	TenantID     string    `gorm:"type:varchar(64);not null;default:default;uniqueIndex:idx_users_tenant_email,priority:1"`
+	Email        string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_users_tenant_email,priority:2"`
+	Name         string    `gorm:"type:varchar(100);not null"`
+	Role         string    `gorm:"type:varchar(20);not null;default:viewer"`
+	PasswordHash string    `gorm:"type:varchar(255)"` // argon2id; empty when the user cannot log in
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
 }
 
 // BeforeCreate hook to generate UUID before creating
@@ -0,0 +1,103 @@
+// Package pagination implements opaque, signed cursors for keyset pagination
+// over (createdAt, id).
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// HeaderNextCursor carries the cursor of the next page
+	HeaderNextCursor = "X-Next-Cursor"
+
+	// HeaderLink carries an RFC 8288 link to the next page
+	HeaderLink = "Link"
+
+	// DefaultLimit is the page size used when the client does not send a limit
+	DefaultLimit = 20
+
+	// MaxLimit is the largest page size a client may request
+	MaxLimit = 100
+)
+
+// ErrInvalidCursor is returned for cursors that are malformed or were not
+// signed with the codec's secret
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor identifies the last item of a page in (createdAt, id) order
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// payload is the signed JSON representation of a Cursor
+type payload struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uuid.UUID `json:"id"`
+}
+
+// Codec encodes and decodes cursors signed with HMAC-SHA256
+type Codec struct {
+	secret []byte
+}
+
+// NewCodec creates a codec signing cursors with secret. An empty secret is
+// replaced by a random one, which makes cursors valid only for the lifetime
+// of the process.
+func NewCodec(secret string) *Codec {
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		rand.Read(key)
+	}
+	return &Codec{secret: key}
+}
+
+// Encode returns the opaque string form of c
+func (k *Codec) Encode(c Cursor) string {
+	// The timestamp keeps its zone offset so it compares equal to the stored
+	// value on drivers that persist times as text
+	data, _ := json.Marshal(payload{CreatedAt: c.CreatedAt, ID: c.ID})
+	body := base64.RawURLEncoding.EncodeToString(data)
+	return body + "." + base64.RawURLEncoding.EncodeToString(k.sign(body))
+}
+
+// Decode verifies and parses a cursor produced by Encode
+func (k *Codec) Decode(s string) (Cursor, error) {
+	body, sig, ok := strings.Cut(s, ".")
+	if !ok {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, k.sign(body)) {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var p payload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return Cursor{CreatedAt: p.CreatedAt, ID: p.ID}, nil
+}
+
+// sign returns the HMAC of the encoded cursor body
+func (k *Codec) sign(body string) []byte {
+	h := hmac.New(sha256.New, k.secret)
+	h.Write([]byte(body))
+	return h.Sum(nil)
+}
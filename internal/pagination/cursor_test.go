@@ -0,0 +1,39 @@
+package pagination
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCodecRoundTrip(t *testing.T) {
+	codec := NewCodec("secret")
+
+	want := Cursor{CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 678000000, time.UTC), ID: uuid.New()}
+	got, err := codec.Decode(codec.Encode(want))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if !got.CreatedAt.Equal(want.CreatedAt) || got.ID != want.ID {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestCodecRejectsTamperedCursor(t *testing.T) {
+	codec := NewCodec("secret")
+	other := NewCodec("other")
+	encoded := codec.Encode(Cursor{CreatedAt: time.Now(), ID: uuid.New()})
+
+	for name, cursor := range map[string]string{
+		"wrong secret": other.Encode(Cursor{CreatedAt: time.Now(), ID: uuid.New()}),
+		"no signature": encoded[:len(encoded)/2],
+		"garbage":      "not-a-cursor",
+		"empty":        "",
+	} {
+		if _, err := codec.Decode(cursor); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("%s: expected ErrInvalidCursor, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"oapi-codegen-layout/internal/models"
+)
+
+// GormAPIKeyRepository implements APIKeyRepository on top of GORM
+type GormAPIKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAPIKeyRepository creates a new GORM-backed API key repository
+func NewGormAPIKeyRepository(db *gorm.DB) *GormAPIKeyRepository {
+	return &GormAPIKeyRepository{
+		db: db,
+	}
+}
+
+// Ensure GormAPIKeyRepository implements APIKeyRepository
+var _ APIKeyRepository = (*GormAPIKeyRepository)(nil)
+
+// Get returns the API key with the given ID
+func (r *GormAPIKeyRepository) Get(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&key).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &key, nil
+}
+
+// GetByPrefix returns the API key with the given lookup prefix
+func (r *GormAPIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := r.db.WithContext(ctx).Where("prefix = ?", prefix).First(&key).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &key, nil
+}
+
+// List returns the API keys matching the filter
+func (r *GormAPIKeyRepository) List(ctx context.Context, filter APIKeyFilter) ([]models.APIKey, error) {
+	query := keyset(r.db.WithContext(ctx), filter.After)
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var keys []models.APIKey
+	if err := query.Find(&keys).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return keys, nil
+}
+
+// Create inserts a new API key
+func (r *GormAPIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	return translateError(r.db.WithContext(ctx).Create(key).Error)
+}
+
+// Revoke marks an active API key as revoked
+func (r *GormAPIKeyRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return affectedOne(r.db.WithContext(ctx).Model(&models.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]any{"revoked_at": at, "updated_at": at}))
+}
+
+// MarkUsed records when an API key was last used
+func (r *GormAPIKeyRepository) MarkUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return affectedOne(r.db.WithContext(ctx).Model(&models.APIKey{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", at))
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+	"oapi-codegen-layout/internal/database"
+	"oapi-codegen-layout/internal/pagination"
+)
+
+// constraintFields maps unique constraints, as reported by each driver, to
+// the field they protect
+var constraintFields = map[string]string{
+	"idx_users_tenant_email": "email", // MySQL, PostgreSQL
+	"users.email":            "email", // SQLite
+
+	"idx_api_keys_prefix": "prefix", // MySQL, PostgreSQL
+	"api_keys.prefix":     "prefix", // SQLite
+
+	"idx_refresh_tokens_token_hash": "token_hash", // MySQL, PostgreSQL
+	"refresh_tokens.token_hash":     "token_hash", // SQLite
+}
+
+// translateError maps GORM and driver errors onto the repository error values
+func translateError(err error) error {
+	var violation *database.UniqueViolation
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrNotFound
+	case errors.As(database.TranslateError(err), &violation):
+		field, ok := constraintFields[violation.Constraint]
+		if !ok {
+			field = violation.Constraint
+		}
+		return &ConflictError{Field: field}
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return ErrConflict
+	default:
+		return err
+	}
+}
+
+// affectedOne returns ErrNotFound when a write matched no rows
+func affectedOne(result *gorm.DB) error {
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// keyset orders a query by (created_at, id) and, when after is set, skips
+// every row up to and including the cursor position
+func keyset(query *gorm.DB, after *pagination.Cursor) *gorm.DB {
+	if after != nil {
+		query = query.Where("(created_at > ? OR (created_at = ? AND id > ?))", after.CreatedAt, after.CreatedAt, after.ID)
+	}
+	return query.Order("created_at ASC").Order("id ASC")
+}
@@ -0,0 +1,628 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"oapi-codegen-layout/internal/models"
+	"oapi-codegen-layout/internal/pagination"
+	"oapi-codegen-layout/internal/tenant"
+)
+
+// MemoryUserRepository implements UserRepository with an in-process map.
+// It mirrors the GORM implementation closely enough for handler tests:
+// IDs and timestamps are assigned on create, emails are unique per tenant,
+// deletes are soft and every method is scoped to the tenant of its context.
+type MemoryUserRepository struct {
+	mu    sync.RWMutex
+	users map[uuid.UUID]models.User
+}
+
+// NewMemoryUserRepository creates an empty in-memory user repository
+func NewMemoryUserRepository() *MemoryUserRepository {
+	return &MemoryUserRepository{
+		users: make(map[uuid.UUID]models.User),
+	}
+}
+
+// Ensure MemoryUserRepository implements UserRepository
+var _ UserRepository = (*MemoryUserRepository)(nil)
+
+// Get returns the user with the given ID
+func (r *MemoryUserRepository) Get(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.users[id]
+	if !ok || user.DeletedAt.Valid || !scope.matches(user.TenantID) {
+		return nil, ErrNotFound
+	}
+	return &user, nil
+}
+
+// GetByEmail returns the user with the given email address
+func (r *MemoryUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if user.Email == email && !user.DeletedAt.Valid && scope.matches(user.TenantID) {
+			return &user, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// List returns the users matching the filter
+func (r *MemoryUserRepository) List(ctx context.Context, filter UserFilter) ([]models.User, error) {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]models.User, 0, len(r.users))
+	for _, user := range r.users {
+		if !user.DeletedAt.Valid && scope.matches(user.TenantID) && isAfter(user.CreatedAt, user.ID, filter.After) {
+			users = append(users, user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return keysetLess(users[i].CreatedAt, users[i].ID, users[j].CreatedAt, users[j].ID)
+	})
+
+	if filter.Limit > 0 && len(users) > filter.Limit {
+		users = users[:filter.Limit]
+	}
+	return users, nil
+}
+
+// Create inserts a new user
+func (r *MemoryUserRepository) Create(ctx context.Context, user *models.User) error {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if user.ID == uuid.Nil {
+		user.ID = uuid.New()
+	}
+	user.TenantID = scope.assign(user.TenantID)
+	if _, ok := r.users[user.ID]; ok {
+		return &ConflictError{Field: "id"}
+	}
+	if r.emailTaken(user.TenantID, user.Email, user.ID) {
+		return &ConflictError{Field: "email"}
+	}
+
+	now := time.Now()
+	user.CreatedAt = now
+	user.UpdatedAt = now
+	r.users[user.ID] = *user
+	return nil
+}
+
+// Update writes all fields of an existing user
+func (r *MemoryUserRepository) Update(ctx context.Context, user *models.User) error {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.users[user.ID]
+	if !ok || existing.DeletedAt.Valid || !scope.matches(existing.TenantID) {
+		return ErrNotFound
+	}
+	user.TenantID = existing.TenantID
+	if r.emailTaken(user.TenantID, user.Email, user.ID) {
+		return &ConflictError{Field: "email"}
+	}
+
+	user.CreatedAt = existing.CreatedAt
+	user.UpdatedAt = time.Now()
+	r.users[user.ID] = *user
+	return nil
+}
+
+// Delete soft-deletes the user with the given ID
+func (r *MemoryUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok || user.DeletedAt.Valid || !scope.matches(user.TenantID) {
+		return ErrNotFound
+	}
+
+	user.DeletedAt.Time = time.Now()
+	user.DeletedAt.Valid = true
+	r.users[id] = user
+	return nil
+}
+
+// emailTaken reports whether another user of the tenant already uses email.
+// Like the database unique index, soft-deleted users still hold their email.
+func (r *MemoryUserRepository) emailTaken(tenantID, email string, except uuid.UUID) bool {
+	for id, user := range r.users {
+		if id != except && user.TenantID == tenantID && user.Email == email {
+			return true
+		}
+	}
+	return false
+}
+
+// MemoryProductRepository implements ProductRepository with an in-process map.
+// Every method is scoped to the tenant of its context.
+type MemoryProductRepository struct {
+	mu       sync.RWMutex
+	products map[uuid.UUID]models.Product
+}
+
+// NewMemoryProductRepository creates an empty in-memory product repository
+func NewMemoryProductRepository() *MemoryProductRepository {
+	return &MemoryProductRepository{
+		products: make(map[uuid.UUID]models.Product),
+	}
+}
+
+// Ensure MemoryProductRepository implements ProductRepository
+var _ ProductRepository = (*MemoryProductRepository)(nil)
+
+// Get returns the product with the given ID
+func (r *MemoryProductRepository) Get(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	product, ok := r.products[id]
+	if !ok || product.DeletedAt.Valid || !scope.matches(product.TenantID) {
+		return nil, ErrNotFound
+	}
+	return &product, nil
+}
+
+// List returns the products matching the filter
+func (r *MemoryProductRepository) List(ctx context.Context, filter ProductFilter) ([]models.Product, error) {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	products := make([]models.Product, 0, len(r.products))
+	for _, product := range r.products {
+		if product.DeletedAt.Valid || !scope.matches(product.TenantID) {
+			continue
+		}
+		if filter.Category != "" && product.Category != filter.Category {
+			continue
+		}
+		if !isAfter(product.CreatedAt, product.ID, filter.After) {
+			continue
+		}
+		products = append(products, product)
+	}
+	sort.Slice(products, func(i, j int) bool {
+		return keysetLess(products[i].CreatedAt, products[i].ID, products[j].CreatedAt, products[j].ID)
+	})
+
+	if filter.Limit > 0 && len(products) > filter.Limit {
+		products = products[:filter.Limit]
+	}
+	return products, nil
+}
+
+// Create inserts a new product
+func (r *MemoryProductRepository) Create(ctx context.Context, product *models.Product) error {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if product.ID == uuid.Nil {
+		product.ID = uuid.New()
+	}
+	product.TenantID = scope.assign(product.TenantID)
+	if _, ok := r.products[product.ID]; ok {
+		return &ConflictError{Field: "id"}
+	}
+
+	now := time.Now()
+	product.CreatedAt = now
+	product.UpdatedAt = now
+	r.products[product.ID] = *product
+	return nil
+}
+
+// Update writes all fields of an existing product
+func (r *MemoryProductRepository) Update(ctx context.Context, product *models.Product) error {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.products[product.ID]
+	if !ok || existing.DeletedAt.Valid || !scope.matches(existing.TenantID) {
+		return ErrNotFound
+	}
+	product.TenantID = existing.TenantID
+
+	product.CreatedAt = existing.CreatedAt
+	product.UpdatedAt = time.Now()
+	r.products[product.ID] = *product
+	return nil
+}
+
+// Delete soft-deletes the product with the given ID
+func (r *MemoryProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	product, ok := r.products[id]
+	if !ok || product.DeletedAt.Valid || !scope.matches(product.TenantID) {
+		return ErrNotFound
+	}
+
+	product.DeletedAt.Time = time.Now()
+	product.DeletedAt.Valid = true
+	r.products[id] = product
+	return nil
+}
+
+// MemoryAPIKeyRepository implements APIKeyRepository with an in-process map.
+// Every method is scoped to the tenant of its context.
+type MemoryAPIKeyRepository struct {
+	mu   sync.RWMutex
+	keys map[uuid.UUID]models.APIKey
+}
+
+// NewMemoryAPIKeyRepository creates an empty in-memory API key repository
+func NewMemoryAPIKeyRepository() *MemoryAPIKeyRepository {
+	return &MemoryAPIKeyRepository{
+		keys: make(map[uuid.UUID]models.APIKey),
+	}
+}
+
+// Ensure MemoryAPIKeyRepository implements APIKeyRepository
+var _ APIKeyRepository = (*MemoryAPIKeyRepository)(nil)
+
+// Get returns the API key with the given ID
+func (r *MemoryAPIKeyRepository) Get(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, ok := r.keys[id]
+	if !ok || !scope.matches(key.TenantID) {
+		return nil, ErrNotFound
+	}
+	return &key, nil
+}
+
+// GetByPrefix returns the API key with the given lookup prefix
+func (r *MemoryAPIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*models.APIKey, error) {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.keys {
+		if key.Prefix == prefix && scope.matches(key.TenantID) {
+			return &key, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// List returns the API keys matching the filter
+func (r *MemoryAPIKeyRepository) List(ctx context.Context, filter APIKeyFilter) ([]models.APIKey, error) {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]models.APIKey, 0, len(r.keys))
+	for _, key := range r.keys {
+		if scope.matches(key.TenantID) && isAfter(key.CreatedAt, key.ID, filter.After) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keysetLess(keys[i].CreatedAt, keys[i].ID, keys[j].CreatedAt, keys[j].ID)
+	})
+
+	if filter.Limit > 0 && len(keys) > filter.Limit {
+		keys = keys[:filter.Limit]
+	}
+	return keys, nil
+}
+
+// Create inserts a new API key
+func (r *MemoryAPIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if key.ID == uuid.Nil {
+		key.ID = uuid.New()
+	}
+	key.TenantID = scope.assign(key.TenantID)
+	if _, ok := r.keys[key.ID]; ok {
+		return &ConflictError{Field: "id"}
+	}
+	for _, existing := range r.keys {
+		if existing.Prefix == key.Prefix {
+			return &ConflictError{Field: "prefix"}
+		}
+	}
+
+	now := time.Now()
+	key.CreatedAt = now
+	key.UpdatedAt = now
+	r.keys[key.ID] = *key
+	return nil
+}
+
+// Revoke marks an active API key as revoked
+func (r *MemoryAPIKeyRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.keys[id]
+	if !ok || key.RevokedAt != nil || !scope.matches(key.TenantID) {
+		return ErrNotFound
+	}
+
+	key.RevokedAt = &at
+	key.UpdatedAt = at
+	r.keys[id] = key
+	return nil
+}
+
+// MarkUsed records when an API key was last used
+func (r *MemoryAPIKeyRepository) MarkUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.keys[id]
+	if !ok || !scope.matches(key.TenantID) {
+		return ErrNotFound
+	}
+
+	key.LastUsedAt = &at
+	r.keys[id] = key
+	return nil
+}
+
+// MemoryRefreshTokenRepository implements RefreshTokenRepository with an
+// in-process map. Every method is scoped to the tenant of its context.
+type MemoryRefreshTokenRepository struct {
+	mu     sync.RWMutex
+	tokens map[uuid.UUID]models.RefreshToken
+}
+
+// NewMemoryRefreshTokenRepository creates an empty in-memory refresh token repository
+func NewMemoryRefreshTokenRepository() *MemoryRefreshTokenRepository {
+	return &MemoryRefreshTokenRepository{
+		tokens: make(map[uuid.UUID]models.RefreshToken),
+	}
+}
+
+// Ensure MemoryRefreshTokenRepository implements RefreshTokenRepository
+var _ RefreshTokenRepository = (*MemoryRefreshTokenRepository)(nil)
+
+// GetByHash returns the refresh token with the given hash
+func (r *MemoryRefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash && scope.matches(token.TenantID) {
+			return &token, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// Create inserts a new refresh token
+func (r *MemoryRefreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if token.ID == uuid.Nil {
+		token.ID = uuid.New()
+	}
+	token.TenantID = scope.assign(token.TenantID)
+	if _, ok := r.tokens[token.ID]; ok {
+		return &ConflictError{Field: "id"}
+	}
+	for _, existing := range r.tokens {
+		if existing.TokenHash == token.TokenHash {
+			return &ConflictError{Field: "token_hash"}
+		}
+	}
+
+	token.CreatedAt = time.Now()
+	r.tokens[token.ID] = *token
+	return nil
+}
+
+// Revoke marks an active refresh token as revoked
+func (r *MemoryRefreshTokenRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, ok := r.tokens[id]
+	if !ok || token.RevokedAt != nil || !scope.matches(token.TenantID) {
+		return ErrNotFound
+	}
+
+	token.RevokedAt = &at
+	r.tokens[id] = token
+	return nil
+}
+
+// RevokeFamily revokes every active token of a family
+func (r *MemoryRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID uuid.UUID, at time.Time) error {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, token := range r.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil && scope.matches(token.TenantID) {
+			token.RevokedAt = &at
+			r.tokens[id] = token
+		}
+	}
+	return nil
+}
+
+// RevokeUser revokes every active token of a user
+func (r *MemoryRefreshTokenRepository) RevokeUser(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, token := range r.tokens {
+		if token.UserID == userID && token.RevokedAt == nil && scope.matches(token.TenantID) {
+			token.RevokedAt = &at
+			r.tokens[id] = token
+		}
+	}
+	return nil
+}
+
+// tenantScope restricts the in-memory repositories to the tenant of a
+// context, like tenant.Plugin does for GORM
+type tenantScope struct {
+	id  string
+	all bool
+}
+
+// scopeOf returns the tenant scope of ctx
+func scopeOf(ctx context.Context) (tenantScope, error) {
+	id, all, err := tenant.Resolve(ctx)
+	if err != nil {
+		return tenantScope{}, err
+	}
+	return tenantScope{id: id, all: all}, nil
+}
+
+// matches reports whether a record of tenantID is visible in the scope
+func (s tenantScope) matches(tenantID string) bool {
+	return s.all || s.id == tenantID
+}
+
+// assign returns the tenant ID to store on a new record
+func (s tenantScope) assign(tenantID string) string {
+	if s.all {
+		return tenantID
+	}
+	return s.id
+}
+
+// keysetLess orders records by creation time, then ID, like the GORM implementation
+func keysetLess(createdA time.Time, idA uuid.UUID, createdB time.Time, idB uuid.UUID) bool {
+	if !createdA.Equal(createdB) {
+		return createdA.Before(createdB)
+	}
+	return idA.String() < idB.String()
+}
+
+// isAfter reports whether a record is ordered after the cursor
+func isAfter(createdAt time.Time, id uuid.UUID, after *pagination.Cursor) bool {
+	if after == nil {
+		return true
+	}
+	return keysetLess(after.CreatedAt, after.ID, createdAt, id)
+}
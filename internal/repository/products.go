@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"oapi-codegen-layout/internal/models"
+)
+
+// GormProductRepository implements ProductRepository on top of GORM
+type GormProductRepository struct {
+	db *gorm.DB
+}
+
+// NewGormProductRepository creates a new GORM-backed product repository
+func NewGormProductRepository(db *gorm.DB) *GormProductRepository {
+	return &GormProductRepository{
+		db: db,
+	}
+}
+
+// Ensure GormProductRepository implements ProductRepository
+var _ ProductRepository = (*GormProductRepository)(nil)
+
+// Get returns the product with the given ID
+func (r *GormProductRepository) Get(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	var product models.Product
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&product).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &product, nil
+}
+
+// List returns the products matching the filter
+func (r *GormProductRepository) List(ctx context.Context, filter ProductFilter) ([]models.Product, error) {
+	query := r.db.WithContext(ctx)
+
+	// Apply category filter if provided
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+
+	// Apply cursor and stable ordering
+	query = keyset(query, filter.After)
+
+	// Apply limit if provided
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var products []models.Product
+	if err := query.Find(&products).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return products, nil
+}
+
+// Create inserts a new product
+func (r *GormProductRepository) Create(ctx context.Context, product *models.Product) error {
+	return translateError(r.db.WithContext(ctx).Create(product).Error)
+}
+
+// Update writes all fields of an existing product
+func (r *GormProductRepository) Update(ctx context.Context, product *models.Product) error {
+	return affectedOne(r.db.WithContext(ctx).Model(product).Select("*").Omit("CreatedAt", "DeletedAt").Updates(product))
+}
+
+// Delete soft-deletes the product with the given ID
+func (r *GormProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return affectedOne(r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Product{}))
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"oapi-codegen-layout/internal/models"
+)
+
+// GormRefreshTokenRepository implements RefreshTokenRepository on top of GORM
+type GormRefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewGormRefreshTokenRepository creates a new GORM-backed refresh token repository
+func NewGormRefreshTokenRepository(db *gorm.DB) *GormRefreshTokenRepository {
+	return &GormRefreshTokenRepository{
+		db: db,
+	}
+}
+
+// Ensure GormRefreshTokenRepository implements RefreshTokenRepository
+var _ RefreshTokenRepository = (*GormRefreshTokenRepository)(nil)
+
+// GetByHash returns the refresh token with the given hash
+func (r *GormRefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &token, nil
+}
+
+// Create inserts a new refresh token
+func (r *GormRefreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	return translateError(r.db.WithContext(ctx).Create(token).Error)
+}
+
+// Revoke marks an active refresh token as revoked
+func (r *GormRefreshTokenRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return affectedOne(r.db.WithContext(ctx).Model(&models.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		UpdateColumn("revoked_at", at))
+}
+
+// RevokeFamily revokes every active token of a family
+func (r *GormRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID uuid.UUID, at time.Time) error {
+	return translateError(r.db.WithContext(ctx).Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		UpdateColumn("revoked_at", at).Error)
+}
+
+// RevokeUser revokes every active token of a user
+func (r *GormRefreshTokenRepository) RevokeUser(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	return translateError(r.db.WithContext(ctx).Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		UpdateColumn("revoked_at", at).Error)
+}
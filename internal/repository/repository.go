@@ -0,0 +1,109 @@
+// Package repository defines the persistence interfaces used by the HTTP
+// handlers and their GORM implementations.
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"oapi-codegen-layout/internal/models"
+	"oapi-codegen-layout/internal/pagination"
+)
+
+var (
+	// ErrNotFound is returned when the requested record does not exist
+	ErrNotFound = errors.New("record not found")
+
+	// ErrConflict is returned when a write violates a uniqueness constraint
+	ErrConflict = errors.New("record conflicts with an existing record")
+)
+
+// ConflictError is returned when a write violates the uniqueness of Field.
+// It matches ErrConflict with errors.Is.
+type ConflictError struct {
+	// Field is the model field whose value is already taken, e.g. "email"
+	Field string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s: %s already in use", ErrConflict, e.Field)
+}
+
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// UserFilter holds the options for listing users. Results are ordered by
+// creation time, then ID.
+type UserFilter struct {
+	// Limit caps the number of returned users; zero means no limit
+	Limit int
+	// After restricts the result to users ordered after the cursor
+	After *pagination.Cursor
+}
+
+// ProductFilter holds the options for listing products. Results are ordered
+// by creation time, then ID.
+type ProductFilter struct {
+	// Category restricts the result to a single category when non-empty
+	Category string
+	// Limit caps the number of returned products; zero means no limit
+	Limit int
+	// After restricts the result to products ordered after the cursor
+	After *pagination.Cursor
+}
+
+// APIKeyFilter holds the options for listing API keys, revoked ones
+// included. Results are ordered by creation time, then ID.
+type APIKeyFilter struct {
+	// Limit caps the number of returned keys; zero means no limit
+	Limit int
+	// After restricts the result to keys ordered after the cursor
+	After *pagination.Cursor
+}
+
+// UserRepository persists users
+type UserRepository interface {
+	Get(ctx context.Context, id uuid.UUID) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	List(ctx context.Context, filter UserFilter) ([]models.User, error)
+	Create(ctx context.Context, user *models.User) error
+	Update(ctx context.Context, user *models.User) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// ProductRepository persists products
+type ProductRepository interface {
+	Get(ctx context.Context, id uuid.UUID) (*models.Product, error)
+	List(ctx context.Context, filter ProductFilter) ([]models.Product, error)
+	Create(ctx context.Context, product *models.Product) error
+	Update(ctx context.Context, product *models.Product) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// APIKeyRepository persists API keys
+type APIKeyRepository interface {
+	Get(ctx context.Context, id uuid.UUID) (*models.APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (*models.APIKey, error)
+	List(ctx context.Context, filter APIKeyFilter) ([]models.APIKey, error)
+	Create(ctx context.Context, key *models.APIKey) error
+	// Revoke marks an active key as revoked; revoking it again returns ErrNotFound
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+	// MarkUsed records when a key was last used to authenticate
+	MarkUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+// RefreshTokenRepository persists refresh tokens
+type RefreshTokenRepository interface {
+	GetByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	Create(ctx context.Context, token *models.RefreshToken) error
+	// Revoke marks an active token as revoked; revoking it again returns ErrNotFound
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+	// RevokeFamily revokes every active token of a family
+	RevokeFamily(ctx context.Context, familyID uuid.UUID, at time.Time) error
+	// RevokeUser revokes every active token of a user
+	RevokeUser(ctx context.Context, userID uuid.UUID, at time.Time) error
+}
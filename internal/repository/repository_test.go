@@ -0,0 +1,235 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"oapi-codegen-layout/internal/models"
+	"oapi-codegen-layout/internal/repository"
+	"oapi-codegen-layout/internal/tenant"
+	"oapi-codegen-layout/internal/testutil"
+)
+
+// userRepositories returns every UserRepository implementation under test
+func userRepositories(t *testing.T) map[string]repository.UserRepository {
+	return map[string]repository.UserRepository{
+		"memory": repository.NewMemoryUserRepository(),
+		"gorm":   repository.NewGormUserRepository(testutil.NewSQLiteDB(t)),
+	}
+}
+
+// productRepositories returns every ProductRepository implementation under test
+func productRepositories(t *testing.T) map[string]repository.ProductRepository {
+	return map[string]repository.ProductRepository{
+		"memory": repository.NewMemoryProductRepository(),
+		"gorm":   repository.NewGormProductRepository(testutil.NewSQLiteDB(t)),
+	}
+}
+
+// apiKeyRepositories returns every APIKeyRepository implementation under test
+func apiKeyRepositories(t *testing.T) map[string]repository.APIKeyRepository {
+	return map[string]repository.APIKeyRepository{
+		"memory": repository.NewMemoryAPIKeyRepository(),
+		"gorm":   repository.NewGormAPIKeyRepository(testutil.NewSQLiteDB(t)),
+	}
+}
+
+func TestUserRepository(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.Default)
+
+	for name, repo := range userRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			user := &models.User{Email: "jane@example.com", Name: "Jane", Role: "editor"}
+			if err := repo.Create(ctx, user); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+			if user.ID == uuid.Nil || user.CreatedAt.IsZero() {
+				t.Fatalf("Create did not assign ID and timestamps: %+v", user)
+			}
+
+			var conflict *repository.ConflictError
+			err := repo.Create(ctx, &models.User{Email: "jane@example.com", Name: "Other Jane"})
+			if !errors.As(err, &conflict) || conflict.Field != "email" || !errors.Is(err, repository.ErrConflict) {
+				t.Fatalf("expected an email conflict, got %v", err)
+			}
+
+			user.Name = "Jane Doe"
+			if err := repo.Update(ctx, user); err != nil {
+				t.Fatalf("Update: %v", err)
+			}
+
+			got, err := repo.Get(ctx, user.ID)
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if got.Name != "Jane Doe" || got.Role != "editor" {
+				t.Fatalf("expected updated name and stored role, got %+v", got)
+			}
+
+			if err := repo.Delete(ctx, user.ID); err != nil {
+				t.Fatalf("Delete: %v", err)
+			}
+			if _, err := repo.Get(ctx, user.ID); !errors.Is(err, repository.ErrNotFound) {
+				t.Fatalf("expected ErrNotFound after delete, got %v", err)
+			}
+			if err := repo.Delete(ctx, user.ID); !errors.Is(err, repository.ErrNotFound) {
+				t.Fatalf("expected ErrNotFound on second delete, got %v", err)
+			}
+			if err := repo.Update(ctx, user); !errors.Is(err, repository.ErrNotFound) {
+				t.Fatalf("expected ErrNotFound when updating a deleted user, got %v", err)
+			}
+
+			users, err := repo.List(ctx, repository.UserFilter{})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if len(users) != 0 {
+				t.Fatalf("expected deleted user to be excluded, got %d users", len(users))
+			}
+		})
+	}
+}
+
+func TestProductRepositoryList(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.Default)
+
+	for name, repo := range productRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			for _, category := range []string{"books", "games", "books", "books"} {
+				if err := repo.Create(ctx, &models.Product{Name: "Item", Price: 10, Category: category}); err != nil {
+					t.Fatalf("Create: %v", err)
+				}
+			}
+
+			products, err := repo.List(ctx, repository.ProductFilter{Category: "books", Limit: 2})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if len(products) != 2 {
+				t.Fatalf("expected 2 products, got %d", len(products))
+			}
+			for _, product := range products {
+				if product.Category != "books" {
+					t.Fatalf("unexpected category %q", product.Category)
+				}
+			}
+
+			if _, err := repo.Get(ctx, uuid.New()); !errors.Is(err, repository.ErrNotFound) {
+				t.Fatalf("expected ErrNotFound, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAPIKeyRepository(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.Default)
+
+	for name, repo := range apiKeyRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			key := &models.APIKey{Name: "billing", Prefix: "0123456789ab", SecretHash: strings.Repeat("0", 64), Scopes: "products:read"}
+			if err := repo.Create(ctx, key); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+
+			var conflict *repository.ConflictError
+			err := repo.Create(ctx, &models.APIKey{Name: "other", Prefix: key.Prefix, SecretHash: key.SecretHash})
+			if !errors.As(err, &conflict) || conflict.Field != "prefix" {
+				t.Fatalf("expected a prefix conflict, got %v", err)
+			}
+
+			got, err := repo.GetByPrefix(ctx, key.Prefix)
+			if err != nil || got.ID != key.ID {
+				t.Fatalf("GetByPrefix: %v %+v", err, got)
+			}
+
+			usedAt := time.Now().Truncate(time.Millisecond)
+			if err := repo.MarkUsed(ctx, key.ID, usedAt); err != nil {
+				t.Fatalf("MarkUsed: %v", err)
+			}
+			if err := repo.Revoke(ctx, key.ID, usedAt); err != nil {
+				t.Fatalf("Revoke: %v", err)
+			}
+			if err := repo.Revoke(ctx, key.ID, usedAt); !errors.Is(err, repository.ErrNotFound) {
+				t.Fatalf("expected ErrNotFound when revoking twice, got %v", err)
+			}
+
+			got, err = repo.Get(ctx, key.ID)
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if got.LastUsedAt == nil || !got.LastUsedAt.Equal(usedAt) || got.RevokedAt == nil {
+				t.Fatalf("expected last use and revocation to be recorded, got %+v", got)
+			}
+
+			keys, err := repo.List(ctx, repository.APIKeyFilter{})
+			if err != nil || len(keys) != 1 {
+				t.Fatalf("List: %v %+v", err, keys)
+			}
+		})
+	}
+}
+
+func TestRefreshTokenRepository(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.Default)
+	db := testutil.NewSQLiteDB(t)
+	user := &models.User{Email: "jane@example.com", Name: "Jane", Role: "viewer"}
+	if err := repository.NewGormUserRepository(db).Create(ctx, user); err != nil {
+		t.Fatalf("Create user: %v", err)
+	}
+
+	for name, repo := range map[string]repository.RefreshTokenRepository{
+		"memory": repository.NewMemoryRefreshTokenRepository(),
+		"gorm":   repository.NewGormRefreshTokenRepository(db),
+	} {
+		t.Run(name, func(t *testing.T) {
+			family := uuid.New()
+			expires := time.Now().Add(time.Hour)
+			first := &models.RefreshToken{UserID: user.ID, FamilyID: family, TokenHash: strings.Repeat("a", 64), ExpiresAt: expires}
+			second := &models.RefreshToken{UserID: user.ID, FamilyID: family, TokenHash: strings.Repeat("b", 64), ExpiresAt: expires}
+			other := &models.RefreshToken{UserID: user.ID, FamilyID: uuid.New(), TokenHash: strings.Repeat("c", 64), ExpiresAt: expires}
+			for _, token := range []*models.RefreshToken{first, second, other} {
+				if err := repo.Create(ctx, token); err != nil {
+					t.Fatalf("Create: %v", err)
+				}
+			}
+
+			var conflict *repository.ConflictError
+			err := repo.Create(ctx, &models.RefreshToken{UserID: user.ID, FamilyID: family, TokenHash: first.TokenHash, ExpiresAt: expires})
+			if !errors.As(err, &conflict) || conflict.Field != "token_hash" {
+				t.Fatalf("expected a token_hash conflict, got %v", err)
+			}
+
+			now := time.Now()
+			if err := repo.Revoke(ctx, first.ID, now); err != nil {
+				t.Fatalf("Revoke: %v", err)
+			}
+			if err := repo.Revoke(ctx, first.ID, now); !errors.Is(err, repository.ErrNotFound) {
+				t.Fatalf("expected ErrNotFound on second revoke, got %v", err)
+			}
+
+			if err := repo.RevokeFamily(ctx, family, now); err != nil {
+				t.Fatalf("RevokeFamily: %v", err)
+			}
+			for token, revoked := range map[*models.RefreshToken]bool{first: true, second: true, other: false} {
+				got, err := repo.GetByHash(ctx, token.TokenHash)
+				if err != nil {
+					t.Fatalf("GetByHash: %v", err)
+				}
+				if (got.RevokedAt != nil) != revoked {
+					t.Fatalf("expected token %s revoked=%v, got %v", got.ID, revoked, got.RevokedAt)
+				}
+			}
+
+			if err := repo.RevokeUser(ctx, user.ID, now); err != nil {
+				t.Fatalf("RevokeUser: %v", err)
+			}
+			if got, err := repo.GetByHash(ctx, other.TokenHash); err != nil || got.RevokedAt == nil {
+				t.Fatalf("expected every token of the user to be revoked, got %v %+v", err, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"oapi-codegen-layout/internal/models"
+)
+
+// GormUserRepository implements UserRepository on top of GORM
+type GormUserRepository struct {
+	db *gorm.DB
+}
+
+// NewGormUserRepository creates a new GORM-backed user repository
+func NewGormUserRepository(db *gorm.DB) *GormUserRepository {
+	return &GormUserRepository{
+		db: db,
+	}
+}
+
+// Ensure GormUserRepository implements UserRepository
+var _ UserRepository = (*GormUserRepository)(nil)
+
+// Get returns the user with the given ID
+func (r *GormUserRepository) Get(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&user).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &user, nil
+}
+
+// GetByEmail returns the user with the given email address
+func (r *GormUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &user, nil
+}
+
+// List returns the users matching the filter
+func (r *GormUserRepository) List(ctx context.Context, filter UserFilter) ([]models.User, error) {
+	query := r.db.WithContext(ctx)
+
+	// Apply cursor and stable ordering
+	query = keyset(query, filter.After)
+
+	// Apply limit if provided
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var users []models.User
+	if err := query.Find(&users).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return users, nil
+}
+
+// Create inserts a new user
+func (r *GormUserRepository) Create(ctx context.Context, user *models.User) error {
+	return translateError(r.db.WithContext(ctx).Create(user).Error)
+}
+
+// Update writes all fields of an existing user
+func (r *GormUserRepository) Update(ctx context.Context, user *models.User) error {
+	return affectedOne(r.db.WithContext(ctx).Model(user).Select("*").Omit("CreatedAt", "DeletedAt").Updates(user))
+}
+
+// Delete soft-deletes the user with the given ID
+func (r *GormUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return affectedOne(r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.User{}))
+}
@@ -1,46 +1,228 @@
 package router
 
 import (
+	"fmt"
+	"log/slog"
+	"oapi-codegen-layout/internal/apierror"
+	"oapi-codegen-layout/internal/apispec"
+	"oapi-codegen-layout/internal/auth"
+	"oapi-codegen-layout/internal/authz"
 	"oapi-codegen-layout/internal/config"
 	"oapi-codegen-layout/internal/handlers"
+	"oapi-codegen-layout/internal/healthcheck"
+	"oapi-codegen-layout/internal/metrics"
+	"oapi-codegen-layout/internal/middleware"
+	"oapi-codegen-layout/internal/pagination"
+	"oapi-codegen-layout/internal/repository"
+	"oapi-codegen-layout/pkg/api/apikeys"
+	authapi "oapi-codegen-layout/pkg/api/auth"
 	"oapi-codegen-layout/pkg/api/health"
 	"oapi-codegen-layout/pkg/api/products"
 	"oapi-codegen-layout/pkg/api/users"
+	"sync/atomic"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"gorm.io/gorm"
 )
 
+// BasePath is the prefix under which all API operations are registered
+const BasePath = "/api/v1"
+
+// Dependencies holds the services the router wires into the handlers
+type Dependencies struct {
+	Users         repository.UserRepository
+	Products      repository.ProductRepository
+	APIKeys       repository.APIKeyRepository
+	RefreshTokens repository.RefreshTokenRepository
+
+	// Verifier checks bearer tokens; nil rejects them
+	Verifier *auth.Verifier
+	// APIKeyAuth authenticates requests with the keys in APIKeys even without
+	// a Verifier. Authentication is disabled when it is unset and Verifier is nil.
+	APIKeyAuth bool
+	// Sessions serves password login; nil leaves the /auth routes unregistered
+	Sessions *auth.Sessions
+	// Logger receives the request logs; nil uses slog.Default()
+	Logger *slog.Logger
+	// Metrics receives the request metrics; nil disables them
+	Metrics *prometheus.Registry
+	// MetricsPath serves Metrics on the API router when set
+	MetricsPath string
+	// Health holds the checks run by the readiness probe; nil runs none
+	Health *healthcheck.Registry
+	// Draining is set when the server shuts down, which fails the readiness probe
+	Draining *atomic.Bool
+}
+
+// NewDependencies creates the GORM-backed dependencies for db
+func NewDependencies(db *gorm.DB) Dependencies {
+	return Dependencies{
+		Users:         repository.NewGormUserRepository(db),
+		Products:      repository.NewGormProductRepository(db),
+		APIKeys:       repository.NewGormAPIKeyRepository(db),
+		RefreshTokens: repository.NewGormRefreshTokenRepository(db),
+	}
+}
+
 // Setup creates and configures the Gin router with all routes and middleware
-func Setup(cfg *config.ServerConfig, db *gorm.DB) *gin.Engine {
+func Setup(cfg *config.ServerConfig, deps Dependencies) (*gin.Engine, error) {
 	// Set Gin mode based on configuration
 	gin.SetMode(cfg.Mode)
 
-	// Create Gin router
-	router := gin.Default()
+	// Decode the embedded specs once; middleware finds the operation of each
+	// route in the index, and the merged document is served to clients
+	domains, err := SpecRegistry().Load()
+	if err != nil {
+		return nil, err
+	}
+	index := apispec.NewIndex(BasePath, specs(domains)...)
+	merged, err := apispec.Merge(APIInfo(), domains)
+	if err != nil {
+		return nil, err
+	}
+	specHandler, err := handlers.NewSpecHandler(merged, domains, cfg.PublicURL, BasePath)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := deps.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	// Create Gin router; every request is logged once, with its request ID
+	router := gin.New()
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(index, logger))
+	var httpMetrics *metrics.HTTP
+	if deps.Metrics != nil {
+		httpMetrics, err = metrics.NewHTTP(deps.Metrics)
+		if err != nil {
+			return nil, fmt.Errorf("failed to register request metrics: %w", err)
+		}
+		router.Use(middleware.Metrics(index, httpMetrics))
+		if deps.MetricsPath != "" {
+			router.GET(deps.MetricsPath, gin.WrapH(metrics.Handler(deps.Metrics)))
+		}
+	}
+	router.Use(middleware.Trace(index))
+	router.Use(middleware.Recovery(logger))
 
-	// Add middleware
-	router.Use(gin.Logger())
-	router.Use(gin.Recovery())
+	// Pagination cursors are signed so clients cannot forge positions
+	cursors := pagination.NewCodec(cfg.CursorSecret)
 
 	// Create separate handlers for each domain
-	userHandler := handlers.NewUserHandler(db)
-	productHandler := handlers.NewProductHandler(db)
-	healthHandler := handlers.NewHealthHandler()
+	userHandler := handlers.NewUserHandler(deps.Users, deps.RefreshTokens, cursors)
+	productHandler := handlers.NewProductHandler(deps.Products, cursors)
+	healthHandler := handlers.NewHealthHandler(deps.Health, deps.Draining)
+	apiKeyHandler := handlers.NewAPIKeyHandler(deps.APIKeys, cursors)
 
 	// Swagger endpoints - serve OpenAPI spec at a different path to avoid conflicts
-	router.GET("/openapi.json", handlers.GetSwaggerJSON)
+	router.GET("/openapi.json", specHandler.GetSwaggerJSON)
+	router.GET("/openapi.yaml", specHandler.GetSwaggerYAML)
+	router.GET("/openapi/:document", specHandler.GetDomainSpec)
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("/openapi.json")))
 
+	// Middleware run by the generated wrappers once the parameters are bound.
+	// Roles are checked before the request body is validated.
+	wrapped := []gin.HandlerFunc{middleware.ValidateRequests(index)}
+	if deps.Verifier != nil {
+		wrapped = append([]gin.HandlerFunc{middleware.Authorize(index, authz.DefaultPolicy, deps.Users)}, wrapped...)
+	}
+
 	// Register routes with the API version prefix
-	apiGroup := router.Group("/api/v1")
+	apiGroup := router.Group(BasePath)
+	timeout, err := middleware.Timeout(index, cfg.RequestTimeout)
+	if err != nil {
+		return nil, err
+	}
+	apiGroup.Use(timeout)
+	if cfg.ValidateResponses {
+		var failures *prometheus.CounterVec
+		if httpMetrics != nil {
+			failures = httpMetrics.ValidationFailures()
+		}
+		apiGroup.Use(middleware.ValidateResponses(index, cfg.Mode, failures))
+	}
+	if deps.Verifier != nil || deps.APIKeyAuth {
+		apiGroup.Use(middleware.Authenticate(index, deps.Verifier, auth.NewAPIKeys(deps.APIKeys)))
+	}
+	apiGroup.Use(middleware.ResolveTenant())
 
 	// Register each handler to its routes
-	users.RegisterHandlers(apiGroup, userHandler)
-	products.RegisterHandlers(apiGroup, productHandler)
-	health.RegisterHandlers(apiGroup, healthHandler)
+	users.RegisterHandlersWithOptions(apiGroup, userHandler, users.GinServerOptions{
+		Middlewares:  middlewareFuncs[users.MiddlewareFunc](wrapped),
+		ErrorHandler: apierror.HandleParameterError,
+	})
+	products.RegisterHandlersWithOptions(apiGroup, productHandler, products.GinServerOptions{
+		Middlewares:  middlewareFuncs[products.MiddlewareFunc](wrapped),
+		ErrorHandler: apierror.HandleParameterError,
+	})
+	health.RegisterHandlersWithOptions(apiGroup, healthHandler, health.GinServerOptions{
+		Middlewares:  middlewareFuncs[health.MiddlewareFunc](wrapped),
+		ErrorHandler: apierror.HandleParameterError,
+	})
+	apikeys.RegisterHandlersWithOptions(apiGroup, apiKeyHandler, apikeys.GinServerOptions{
+		Middlewares:  middlewareFuncs[apikeys.MiddlewareFunc](wrapped),
+		ErrorHandler: apierror.HandleParameterError,
+	})
+	if deps.Sessions != nil {
+		authapi.RegisterHandlersWithOptions(apiGroup, handlers.NewAuthHandler(deps.Sessions), authapi.GinServerOptions{
+			Middlewares:  middlewareFuncs[authapi.MiddlewareFunc](wrapped),
+			ErrorHandler: apierror.HandleParameterError,
+		})
+	}
+
+	return router, nil
+}
+
+// middlewareFuncs converts handlers to the MiddlewareFunc type of a generated package
+func middlewareFuncs[M ~func(*gin.Context)](handlers []gin.HandlerFunc) []M {
+	funcs := make([]M, len(handlers))
+	for i, h := range handlers {
+		funcs[i] = M(h)
+	}
+	return funcs
+}
+
+// SpecRegistry registers the embedded spec of every API domain
+func SpecRegistry() *apispec.Registry {
+	registry := apispec.NewRegistry()
+	registry.Register("users", users.GetSwagger)
+	registry.Register("products", products.GetSwagger)
+	registry.Register("health", health.GetSwagger)
+	registry.Register("apikeys", apikeys.GetSwagger)
+	registry.Register("auth", authapi.GetSwagger)
+	return registry
+}
+
+// APIInfo describes the merged OpenAPI document
+func APIInfo() *openapi3.Info {
+	return &openapi3.Info{
+		Title:       "Example API",
+		Description: "Example API using oapi-codegen with Gin - Split Handlers",
+		Version:     "1.0.0",
+	}
+}
+
+// LoadSpecs decodes the OpenAPI spec embedded in each generated package
+func LoadSpecs() ([]*openapi3.T, error) {
+	domains, err := SpecRegistry().Load()
+	if err != nil {
+		return nil, err
+	}
+	return specs(domains), nil
+}
 
-	return router
+// specs returns the specs of domains
+func specs(domains []apispec.Domain) []*openapi3.T {
+	specs := make([]*openapi3.T, len(domains))
+	for i, domain := range domains {
+		specs[i] = domain.Spec
+	}
+	return specs
 }
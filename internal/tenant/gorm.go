@@ -0,0 +1,80 @@
+package tenant
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// fieldName is the model field that holds the tenant of a row
+const fieldName = "TenantID"
+
+// Plugin scopes every GORM statement on a model with a TenantID field to the
+// tenant of the statement context: queries, updates and deletes only match
+// the tenant's rows, and creates and updates write its ID. Statements
+// without a tenant in their context fail with ErrMissingTenant. Raw SQL and
+// statements without a model are not scoped.
+type Plugin struct{}
+
+// Name returns the name of the plugin
+func (Plugin) Name() string {
+	return "tenant"
+}
+
+// Initialize registers the scoping callbacks
+func (Plugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("tenant:create", assign); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("tenant:query", restrict); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("tenant:row", restrict); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("tenant:update", func(db *gorm.DB) {
+		restrict(db)
+		// Rows cannot be moved to another tenant by writing a struct
+		if _, ok := db.Statement.Dest.(map[string]any); !ok {
+			assign(db)
+		}
+	}); err != nil {
+		return err
+	}
+	return callbacks.Delete().Before("gorm:delete").Register("tenant:delete", restrict)
+}
+
+// scoped reports whether the statement targets a tenant-owned model and
+// returns its tenant
+func scoped(db *gorm.DB) (id string, all, ok bool) {
+	if db.Error != nil || db.Statement.Schema == nil || db.Statement.Schema.LookUpField(fieldName) == nil {
+		return "", false, false
+	}
+	id, all, err := Resolve(db.Statement.Context)
+	if err != nil {
+		db.AddError(err)
+		return "", false, false
+	}
+	return id, all, true
+}
+
+// restrict adds a tenant_id condition to the statement
+func restrict(db *gorm.DB) {
+	id, all, ok := scoped(db)
+	if !ok || all {
+		return
+	}
+	column := db.Statement.Schema.LookUpField(fieldName).DBName
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: column}, Value: id},
+	}})
+}
+
+// assign writes the tenant ID into the rows of the statement
+func assign(db *gorm.DB) {
+	id, all, ok := scoped(db)
+	if !ok || all {
+		return
+	}
+	db.Statement.SetColumn(fieldName, id, true)
+}
@@ -0,0 +1,56 @@
+package tenant_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"oapi-codegen-layout/internal/models"
+	"oapi-codegen-layout/internal/tenant"
+	"oapi-codegen-layout/internal/testutil"
+)
+
+func TestPlugin(t *testing.T) {
+	db := testutil.NewSQLiteDB(t)
+	acme := tenant.WithID(context.Background(), "acme")
+	globex := tenant.WithID(context.Background(), "globex")
+
+	product := &models.Product{Name: "Widget", Price: 9.99, Category: "tools"}
+	if err := db.WithContext(acme).Create(product).Error; err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if product.TenantID != "acme" {
+		t.Fatalf("expected tenant acme to be assigned, got %q", product.TenantID)
+	}
+
+	var count int64
+	if err := db.WithContext(globex).Model(&models.Product{}).Count(&count).Error; err != nil || count != 0 {
+		t.Fatalf("expected globex to see no products, got %d (%v)", count, err)
+	}
+	result := db.WithContext(globex).Model(&models.Product{}).Where("id = ?", product.ID).Update("name", "Stolen")
+	if result.Error != nil || result.RowsAffected != 0 {
+		t.Fatalf("expected globex to update nothing, got %d rows (%v)", result.RowsAffected, result.Error)
+	}
+	result = db.WithContext(globex).Delete(&models.Product{}, "id = ?", product.ID)
+	if result.Error != nil || result.RowsAffected != 0 {
+		t.Fatalf("expected globex to delete nothing, got %d rows (%v)", result.RowsAffected, result.Error)
+	}
+
+	// Saving a row loaded from another tenant must not move it
+	product.TenantID = "globex"
+	if err := db.WithContext(acme).Save(product).Error; err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := db.WithContext(acme).Model(&models.Product{}).Count(&count).Error; err != nil || count != 1 {
+		t.Fatalf("expected acme to keep its product, got %d (%v)", count, err)
+	}
+
+	if err := db.WithContext(tenant.AllTenants(context.Background())).Model(&models.Product{}).Count(&count).Error; err != nil || count != 1 {
+		t.Fatalf("expected all tenants to see the product, got %d (%v)", count, err)
+	}
+
+	err := db.WithContext(context.Background()).First(&models.Product{}).Error
+	if !errors.Is(err, tenant.ErrMissingTenant) {
+		t.Fatalf("expected ErrMissingTenant without a tenant, got %v", err)
+	}
+}
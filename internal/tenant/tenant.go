@@ -0,0 +1,68 @@
+// Package tenant carries the tenant of a request through its context and
+// scopes database access to it.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"regexp"
+)
+
+// Header is the request header that selects the tenant of anonymous callers
+const Header = "X-Tenant-ID"
+
+// Default is the tenant of callers that do not name one. Rows that existed
+// before tenants were introduced belong to it.
+const Default = "default"
+
+// ErrMissingTenant is returned for database access without a tenant in the context
+var ErrMissingTenant = errors.New("no tenant in context")
+
+// idPattern restricts tenant IDs to short lower-case slugs
+var idPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// contextKey is the context key under which the scope is stored
+type contextKey struct{}
+
+// scope is the tenant a context is restricted to; all lifts the restriction
+type scope struct {
+	id  string
+	all bool
+}
+
+// ValidID reports whether id is a well-formed tenant ID
+func ValidID(id string) bool {
+	return idPattern.MatchString(id)
+}
+
+// WithID returns a context restricted to the tenant with the given ID
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, scope{id: id})
+}
+
+// AllTenants returns a context that may access the rows of every tenant. It
+// is meant for lookups by globally unique secrets, such as API keys; rows
+// created with it must set their TenantID explicitly.
+func AllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKey{}, scope{all: true})
+}
+
+// FromContext returns the tenant a context is restricted to
+func FromContext(ctx context.Context) (string, bool) {
+	s, ok := ctx.Value(contextKey{}).(scope)
+	if !ok || s.all {
+		return "", false
+	}
+	return s.id, true
+}
+
+// Resolve returns the tenant a context is restricted to, or all = true when
+// it may access every tenant. Contexts without either fail with
+// ErrMissingTenant, so that unscoped access cannot happen by accident.
+func Resolve(ctx context.Context) (id string, all bool, err error) {
+	s, ok := ctx.Value(contextKey{}).(scope)
+	if !ok {
+		return "", false, ErrMissingTenant
+	}
+	return s.id, s.all, nil
+}
@@ -0,0 +1,343 @@
+// Package testutil builds the full HTTP stack from router.Setup on top of
+// in-process storage so that handler tests run with plain "go test".
+package testutil
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+	"oapi-codegen-layout/internal/apispec"
+	"oapi-codegen-layout/internal/auth"
+	"oapi-codegen-layout/internal/authz"
+	"oapi-codegen-layout/internal/config"
+	"oapi-codegen-layout/internal/database"
+	"oapi-codegen-layout/internal/database/migrate"
+	"oapi-codegen-layout/internal/healthcheck"
+	"oapi-codegen-layout/internal/metrics"
+	"oapi-codegen-layout/internal/models"
+	"oapi-codegen-layout/internal/repository"
+	"oapi-codegen-layout/internal/router"
+	"oapi-codegen-layout/internal/tenant"
+	apimodels "oapi-codegen-layout/pkg/api/models"
+)
+
+// BasePath is the prefix under which the API routes are registered
+const BasePath = router.BasePath
+
+// JWTSecret is the HS256 secret test servers verify bearer tokens with
+const JWTSecret = "testutil-jwt-secret"
+
+// Server is a test instance of the API backed by in-process storage
+type Server struct {
+	// Engine is the Gin engine returned by router.Setup
+	Engine *gin.Engine
+	// Deps are the dependencies the engine was built with; use them to seed data
+	Deps router.Dependencies
+	// DB is the SQLite database when the server was created with WithSQLite
+	DB *gorm.DB
+	// Token is sent as bearer token by Do. It authenticates as Admin and
+	// grants every scope used by the specs; set it to "" to send
+	// unauthenticated requests.
+	Token string
+	// Admin is the user with the admin role that Token authenticates as. It
+	// is only created when authentication is enabled.
+	Admin *models.User
+}
+
+// options configures NewServer
+type options struct {
+	sqlite  bool
+	server  config.ServerConfig
+	auth    config.AuthConfig
+	logger  *slog.Logger
+	metrics bool
+}
+
+// Option customizes the server built by NewServer
+type Option func(*options)
+
+// WithSQLite backs the server with a migrated in-memory SQLite database
+// instead of the in-memory repositories
+func WithSQLite() Option {
+	return func(o *options) {
+		o.sqlite = true
+	}
+}
+
+// WithServerConfig overrides the server configuration passed to router.Setup
+func WithServerConfig(cfg config.ServerConfig) Option {
+	return func(o *options) {
+		o.server = cfg
+	}
+}
+
+// WithAuthConfig overrides the authentication configuration. By default
+// tokens are verified and issued with JWTSecret; an empty configuration
+// disables authentication. Token lifetimes must be set when JWTSecret is.
+func WithAuthConfig(cfg config.AuthConfig) Option {
+	return func(o *options) {
+		o.auth = cfg
+	}
+}
+
+// WithLogger sends the request logs of the server to logger
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithMetrics records request metrics and serves them at /metrics
+func WithMetrics() Option {
+	return func(o *options) {
+		o.metrics = true
+	}
+}
+
+// NewServer builds the full Gin engine with router.Setup. By default the
+// handlers are backed by the in-memory repositories.
+func NewServer(t testing.TB, opts ...Option) *Server {
+	t.Helper()
+
+	o := options{
+		// Responses are validated so handlers cannot drift from the spec
+		server: config.ServerConfig{Mode: gin.TestMode, ValidateResponses: true},
+		auth: config.AuthConfig{
+			JWTSecret:       JWTSecret,
+			AccessTokenTTL:  15 * time.Minute,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	s := &Server{}
+	if o.sqlite {
+		s.DB = NewSQLiteDB(t)
+		s.Deps = router.NewDependencies(s.DB)
+	} else {
+		s.Deps = router.Dependencies{
+			Users:         repository.NewMemoryUserRepository(),
+			Products:      repository.NewMemoryProductRepository(),
+			APIKeys:       repository.NewMemoryAPIKeyRepository(),
+			RefreshTokens: repository.NewMemoryRefreshTokenRepository(),
+		}
+	}
+
+	s.Deps.Logger = o.logger
+	s.Deps.Draining = new(atomic.Bool)
+	s.Deps.Health = healthcheck.NewRegistry(time.Second)
+	if o.metrics {
+		s.Deps.Metrics, s.Deps.MetricsPath = metrics.NewRegistry(nil), "/metrics"
+	}
+	if o.auth.Enabled() {
+		verifier, err := auth.NewVerifier(o.auth)
+		if err != nil {
+			t.Fatalf("failed to create token verifier: %v", err)
+		}
+		s.Deps.Verifier = verifier
+	}
+	s.Deps.APIKeyAuth = o.auth.APIKeys
+	if o.auth.JWTSecret != "" {
+		sessions, err := auth.NewSessions(o.auth, s.Deps.Users, s.Deps.RefreshTokens)
+		if err != nil {
+			t.Fatalf("failed to create session service: %v", err)
+		}
+		s.Deps.Sessions = sessions
+	}
+
+	engine, err := router.Setup(&o.server, s.Deps)
+	if err != nil {
+		t.Fatalf("failed to set up router: %v", err)
+	}
+	s.Engine = engine
+
+	if o.auth.JWTSecret != "" {
+		s.Admin = &models.User{Email: "admin@example.com", Name: "Admin", Role: string(authz.RoleAdmin)}
+		if err := s.Deps.Users.Create(tenant.WithID(context.Background(), tenant.Default), s.Admin); err != nil {
+			t.Fatalf("failed to create admin user: %v", err)
+		}
+		s.Token = NewToken(t, o.auth.JWTSecret, s.Admin.ID.String(), allScopes(t)...)
+	}
+	return s
+}
+
+// NewToken signs an HS256 access token for subject that grants scopes and
+// expires in an hour
+func NewToken(t testing.TB, secret, subject string, scopes ...string) string {
+	t.Helper()
+	return NewTenantToken(t, secret, "", subject, scopes...)
+}
+
+// NewTenantToken is like NewToken but binds the token to a tenant
+func NewTenantToken(t testing.TB, secret, tenantID, subject string, scopes ...string) string {
+	t.Helper()
+
+	claims := auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Scope:  strings.Join(scopes, " "),
+		Tenant: tenantID,
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+// allScopes returns every scope required by an operation of the specs
+func allScopes(t testing.TB) []string {
+	t.Helper()
+
+	specs, err := router.LoadSpecs()
+	if err != nil {
+		t.Fatalf("failed to load specs: %v", err)
+	}
+	return apispec.NewIndex(BasePath, specs...).Scopes()
+}
+
+// NewSQLiteDB opens an in-memory SQLite database with all migrations applied.
+// The database is closed when the test finishes.
+func NewSQLiteDB(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	cfg := &config.DatabaseConfig{Driver: config.DriverSQLite, Name: config.SQLiteInMemory}
+	db, err := database.InitDB(cfg)
+	if err != nil {
+		t.Fatalf("failed to open SQLite database: %v", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to access database pool: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	migrator, err := migrate.New(sqlDB, cfg.Driver)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	return db
+}
+
+// Response is a recorded HTTP response
+type Response struct {
+	Code   int
+	Header http.Header
+	Body   []byte
+}
+
+// Do sends a request to the server. path is relative to BasePath and body,
+// when not nil, is encoded as JSON.
+func (s *Server) Do(t testing.TB, method, path string, body any) *Response {
+	t.Helper()
+	return s.Serve(s.NewRequest(t, method, path, body))
+}
+
+// NewRequest prepares the request Do sends, for tests that need to adjust
+// it before passing it to Serve
+func (s *Server) NewRequest(t testing.TB, method, path string, body any) *http.Request {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("failed to encode request body: %v", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req := httptest.NewRequest(method, BasePath+path, reader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+	return req
+}
+
+// Serve sends a prepared request to the server
+func (s *Server) Serve(req *http.Request) *Response {
+	rec := httptest.NewRecorder()
+	s.Engine.ServeHTTP(rec, req)
+
+	return &Response{
+		Code:   rec.Code,
+		Header: rec.Header(),
+		Body:   rec.Body.Bytes(),
+	}
+}
+
+// Decode unmarshals the response body into v
+func (r *Response) Decode(t testing.TB, v any) {
+	t.Helper()
+
+	if err := json.Unmarshal(r.Body, v); err != nil {
+		t.Fatalf("failed to decode response body %q: %v", r.Body, err)
+	}
+}
+
+// Expect decodes a successful response into T after checking the status code
+func Expect[T any](t testing.TB, r *Response, status int) T {
+	t.Helper()
+
+	if r.Code != status {
+		t.Fatalf("expected status %d, got %d: %s", status, r.Code, r.Body)
+	}
+
+	var v T
+	r.Decode(t, &v)
+	return v
+}
+
+// ExpectError checks that the response is an apimodels.Error with the given
+// status and code, and returns it for further assertions
+func ExpectError(t testing.TB, r *Response, status int, code string) apimodels.Error {
+	t.Helper()
+
+	apiErr := Expect[apimodels.Error](t, r, status)
+	if apiErr.Code != code {
+		t.Fatalf("expected error code %q, got %q (%s)", code, apiErr.Code, apiErr.Message)
+	}
+	return apiErr
+}
+
+// CreateUser creates a user through the API and returns it
+func (s *Server) CreateUser(t testing.TB, req apimodels.CreateUserRequest) apimodels.User {
+	t.Helper()
+	return Expect[apimodels.User](t, s.Do(t, http.MethodPost, "/users", req), http.StatusCreated)
+}
+
+// CreateProduct creates a product through the API and returns it
+func (s *Server) CreateProduct(t testing.TB, req apimodels.CreateProductRequest) apimodels.Product {
+	t.Helper()
+	return Expect[apimodels.Product](t, s.Do(t, http.MethodPost, "/products", req), http.StatusCreated)
+}
+
+// CreateAPIKey creates an API key through the API and returns it with its secret
+func (s *Server) CreateAPIKey(t testing.TB, req apimodels.CreateAPIKeyRequest) apimodels.CreatedAPIKey {
+	t.Helper()
+	return Expect[apimodels.CreatedAPIKey](t, s.Do(t, http.MethodPost, "/api-keys", req), http.StatusCreated)
+}
@@ -0,0 +1,88 @@
+package tracing
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// Plugin starts a span for every GORM statement as a child of the span in
+// the statement context. Spans carry the SQL without its parameters, which
+// may hold credentials.
+type Plugin struct{}
+
+// Name returns the name of the plugin
+func (Plugin) Name() string {
+	return "tracing"
+}
+
+// Initialize registers the callbacks that start and end the spans
+func (Plugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().Before("gorm:create").Register("tracing:before_create", start("create")),
+		callbacks.Create().After("gorm:create").Register("tracing:after_create", end),
+		callbacks.Query().Before("gorm:query").Register("tracing:before_query", start("query")),
+		callbacks.Query().After("gorm:query").Register("tracing:after_query", end),
+		callbacks.Update().Before("gorm:update").Register("tracing:before_update", start("update")),
+		callbacks.Update().After("gorm:update").Register("tracing:after_update", end),
+		callbacks.Delete().Before("gorm:delete").Register("tracing:before_delete", start("delete")),
+		callbacks.Delete().After("gorm:delete").Register("tracing:after_delete", end),
+		callbacks.Row().Before("gorm:row").Register("tracing:before_row", start("row")),
+		callbacks.Row().After("gorm:row").Register("tracing:after_row", end),
+		callbacks.Raw().Before("gorm:raw").Register("tracing:before_raw", start("raw")),
+		callbacks.Raw().After("gorm:raw").Register("tracing:after_raw", end),
+	)
+}
+
+// start returns a callback that starts the span of a statement
+func start(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		attrs := []attribute.KeyValue{
+			dbSystem(db.Dialector.Name()),
+			semconv.DBOperationName(operation),
+		}
+		if db.Statement.Table != "" {
+			attrs = append(attrs, semconv.DBCollectionName(db.Statement.Table))
+		}
+		ctx, _ := Tracer().Start(db.Statement.Context, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+		db.Statement.Context = ctx
+	}
+}
+
+// end records the query and its outcome and ends the span of a statement
+func end(db *gorm.DB) {
+	span := trace.SpanFromContext(db.Statement.Context)
+	if !span.IsRecording() {
+		return
+	}
+	defer span.End()
+
+	span.SetAttributes(
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		attribute.Int64("db.response.returned_rows", db.Statement.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
+
+// dbSystem maps a GORM dialector name to the semantic convention value
+func dbSystem(dialector string) attribute.KeyValue {
+	switch dialector {
+	case "mysql":
+		return semconv.DBSystemNameMySQL
+	case "postgres":
+		return semconv.DBSystemNamePostgreSQL
+	case "sqlite":
+		return semconv.DBSystemNameSQLite
+	default:
+		return semconv.DBSystemNameKey.String(dialector)
+	}
+}
@@ -0,0 +1,62 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"oapi-codegen-layout/internal/config"
+	"oapi-codegen-layout/internal/models"
+	"oapi-codegen-layout/internal/tenant"
+	"oapi-codegen-layout/internal/testutil"
+	"oapi-codegen-layout/internal/tracing"
+)
+
+func TestSetupRejectsUnknownExporter(t *testing.T) {
+	_, err := tracing.Setup(context.Background(), config.TracingConfig{Enabled: true, Exporter: "zipkin", SampleRatio: 1})
+	if err == nil {
+		t.Fatal("expected an unknown exporter to be rejected")
+	}
+}
+
+func TestPlugin(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	db := testutil.NewSQLiteDB(t)
+	ctx, parent := tracing.Tracer().Start(tenant.WithID(context.Background(), tenant.Default), "parent")
+	product := &models.Product{Name: "Widget", Price: 9.99, Category: "tools"}
+	if err := db.WithContext(ctx).Create(product).Error; err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := db.WithContext(ctx).First(&models.Product{}, "id = ?", product.ID).Error; err != nil {
+		t.Fatalf("First: %v", err)
+	}
+	parent.End()
+
+	var names []string
+	for _, span := range recorder.Ended() {
+		if span.Name() == "parent" {
+			continue
+		}
+		names = append(names, span.Name())
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("span %s is not a child of the request span", span.Name())
+		}
+		attrs := attribute.NewSet(span.Attributes()...)
+		if v, _ := attrs.Value("db.system.name"); v.AsString() != "sqlite" {
+			t.Errorf("span %s has db.system.name %q", span.Name(), v.AsString())
+		}
+		if v, _ := attrs.Value("db.query.text"); v.AsString() == "" {
+			t.Errorf("span %s has no query text", span.Name())
+		}
+	}
+	if len(names) != 2 || names[0] != "gorm.create" || names[1] != "gorm.query" {
+		t.Fatalf("expected gorm.create and gorm.query spans, got %v", names)
+	}
+}
@@ -0,0 +1,442 @@
+// Package apikeys provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+package apikeys
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	externalRef0 "oapi-codegen-layout/pkg/api/models"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	ApiKeyAuthScopes = "apiKeyAuth.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// APIKey defines model for APIKey.
+type APIKey struct {
+	// CreatedAt When the key was created
+	CreatedAt time.Time `json:"createdAt"`
+
+	// ExpiresAt When the key stops working; absent for keys without expiry
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// Id Unique identifier of the key
+	Id openapi_types.UUID `json:"id"`
+
+	// LastUsedAt When the key last authenticated a request
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+
+	// Name Name that tells what the key is used for
+	Name string `json:"name"`
+
+	// Prefix Public part of the key, shown to tell keys apart
+	Prefix string `json:"prefix"`
+
+	// RevokedAt When the key was revoked; absent for active keys
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+
+	// Scopes Scopes granted to requests authenticated with the key
+	Scopes []string `json:"scopes"`
+}
+
+// CreateAPIKeyRequest defines model for CreateAPIKeyRequest.
+type CreateAPIKeyRequest struct {
+	// ExpiresAt When the key stops working; keys without expiry stay valid until revoked
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// Name Name that tells what the key is used for
+	Name string `json:"name"`
+
+	// Scopes Scopes to grant, such as products:read; at most 1000 characters when joined by spaces
+	Scopes []string `json:"scopes"`
+}
+
+// CreatedAPIKey defines model for CreatedAPIKey.
+type CreatedAPIKey struct {
+	// CreatedAt When the key was created
+	CreatedAt time.Time `json:"createdAt"`
+
+	// ExpiresAt When the key stops working; absent for keys without expiry
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// Id Unique identifier of the key
+	Id openapi_types.UUID `json:"id"`
+
+	// Key The full key to send in the X-API-Key header. It is only returned once.
+	Key string `json:"key"`
+
+	// LastUsedAt When the key last authenticated a request
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+
+	// Name Name that tells what the key is used for
+	Name string `json:"name"`
+
+	// Prefix Public part of the key, shown to tell keys apart
+	Prefix string `json:"prefix"`
+
+	// RevokedAt When the key was revoked; absent for active keys
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+
+	// Scopes Scopes granted to requests authenticated with the key
+	Scopes []string `json:"scopes"`
+}
+
+// Error defines model for Error.
+type Error struct {
+	// Code Machine-readable error code, such as not_found
+	Code string `json:"code"`
+
+	// Details Per-field problems, e.g. for request validation failures
+	Details *[]ErrorDetail `json:"details,omitempty"`
+
+	// Field Request field the error refers to, when it concerns a single field
+	Field *string `json:"field,omitempty"`
+
+	// Message Human-readable description of the error
+	Message string `json:"message"`
+}
+
+// ErrorDetail defines model for .
+type ErrorDetail struct {
+	// Field Location of the problem, e.g. "name" for a body property or "limit" for a query parameter
+	Field string `json:"field"`
+
+	// Message Human-readable description of the problem
+	Message string `json:"message"`
+}
+
+// Problem defines model for Problem.
+type Problem struct {
+	// Code Machine-readable error code, such as not_found
+	Code string `json:"code"`
+
+	// Detail Explanation specific to this occurrence of the problem
+	Detail *string `json:"detail,omitempty"`
+
+	// Details Per-field problems, e.g. for request validation failures
+	Details *[]ErrorDetail `json:"details,omitempty"`
+
+	// Field Request field the error refers to, when it concerns a single field
+	Field *string `json:"field,omitempty"`
+
+	// Instance URI reference of the request that caused the problem
+	Instance *string `json:"instance,omitempty"`
+
+	// Message Human-readable description of the error
+	Message string `json:"message"`
+
+	// Status HTTP status code
+	Status int `json:"status"`
+
+	// Title Short summary of the problem type
+	Title string `json:"title"`
+
+	// Type URI reference identifying the problem type
+	Type string `json:"type"`
+}
+
+// ListApiKeysParams defines parameters for ListApiKeys.
+type ListApiKeysParams struct {
+	// Limit Maximum number of keys to return
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor from the X-Next-Cursor header of the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// CreateApiKeyJSONRequestBody defines body for CreateApiKey for application/json ContentType.
+type CreateApiKeyJSONRequestBody = CreateAPIKeyRequest
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// List API keys, revoked ones included
+	// (GET /api-keys)
+	ListApiKeys(c *gin.Context, params ListApiKeysParams)
+	// Create an API key
+	// (POST /api-keys)
+	CreateApiKey(c *gin.Context)
+	// Revoke an API key
+	// (DELETE /api-keys/{apiKeyId})
+	RevokeApiKey(c *gin.Context, apiKeyId openapi_types.UUID)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandler       func(*gin.Context, error, int)
+}
+
+type MiddlewareFunc func(c *gin.Context)
+
+// ListApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) ListApiKeys(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{"api-keys:admin"})
+
+	c.Set(ApiKeyAuthScopes, []string{"api-keys:admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListApiKeysParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListApiKeys(c, params)
+}
+
+// CreateApiKey operation middleware
+func (siw *ServerInterfaceWrapper) CreateApiKey(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"api-keys:admin"})
+
+	c.Set(ApiKeyAuthScopes, []string{"api-keys:admin"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateApiKey(c)
+}
+
+// RevokeApiKey operation middleware
+func (siw *ServerInterfaceWrapper) RevokeApiKey(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "apiKeyId" -------------
+	var apiKeyId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "apiKeyId", c.Param("apiKeyId"), &apiKeyId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter apiKeyId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"api-keys:admin"})
+
+	c.Set(ApiKeyAuthScopes, []string{"api-keys:admin"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RevokeApiKey(c, apiKeyId)
+}
+
+// GinServerOptions provides options for the Gin server.
+type GinServerOptions struct {
+	BaseURL      string
+	Middlewares  []MiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router gin.IRouter, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, GinServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.GET(options.BaseURL+"/api-keys", wrapper.ListApiKeys)
+	router.POST(options.BaseURL+"/api-keys", wrapper.CreateApiKey)
+	router.DELETE(options.BaseURL+"/api-keys/:apiKeyId", wrapper.RevokeApiKey)
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+xZb2/bOPL+KgP+9sXvbmXHaXvYnBf3Itdusd52t0Gaogc0uYKWxhYbiVTJUWJd4e9+",
+	"GJKSHUv5s0VxuNz6nS1THM7MM88zQ38RqSkro1GTE9MvwqU5ltJ/PD6ZvcKGP1XWVGhJoX+eWpSE2THx",
+	"lwxdalVFymgxFe9z1EA5wiU2cC0dxLUiEQtjS0liKjJJOCJVokgENRWKqXBklV6KdSJwVSmL7t69HZnK",
+	"wbWxl0ovfwQ5d6gJFsbyrw6uFeWmJvDbNQ+2rrK+2Xdafa4RVIaa1EKhBbNoj7G9cV2rbGjPQjp65x4Q",
+	"Ll4IsqacDaUcNZBg8XONjh7sgZYl9u38JksEyiUBYVE4uPYfo13loHaYcfCGdqwsLtSqv+dJPS9UCpW0",
+	"tBWRBFxurjWQ8aZCNiQvGtrb4pW5fCCS4tobuZYpqSu/xD04Qi41Fbq+wbf+OSyt1Bx6Mm3s3U5SGFtb",
+	"AFCEpd+uZyk+kNbKRqy9u59rZTET0w/Cg8Vnqwtxd7Zkq8Quun3M/BOmxBs/97+G8jyNAOlV6VdW0kD5",
+	"gCPZwJUsVAa1JlW0ufjPobKUq9eol5SL6eFkkohS6e77788xmZDmBFyd5iAdVNZkdUpualEyxAhK4wgO",
+	"J5MJpLm0MiW0fELU8MkojRnMG3CVTNFtY6CSRGjZ1D8/yNG/JqO/fhxdfP//060vf/rzd0MhKpWehV0O",
+	"70FOBE308XZ8ZBv+lkXxZiGmH76I7ywuxFT838GG9Q8i5R/ISn2Mnz/Gd9fJLqwusenH9SxHWNSh3Dm6",
+	"DnUGKmDsH6Pjk9noFTaQo8zQjmFGnF2jiwYsUm05mkanOO7HZcd1Nt53+CLZOc4xaLwumlZ84PhkFrhJ",
+	"6bSoM6WXoMiBw9SiD9hP1ho7IHQmG4DtrzLNlcYRQ0XOCwTkt4EXbwClDX1cmFoPSkKGJFUxgM8TtKOF",
+	"wiJjQM4LLF0COF6OPdlFOgqFKPkNWEhV1HYHgjdc8Lv1Db02adghMnc0F62de4ydi8CxMDdZA3HbBoyF",
+	"c1GoUlG34HONtmElkCUSDqpIic7J5UA0f65LqTex3Ppx52z3giO4ujHVA0oiVqOlGfHDUWCkkPgXPh19",
+	"xk5ui17kXAiporyFgMUFWqaXJDCFIkgZ2FY7kOCUXhYI7TG/ZYi8+XsD5OF8R3zWiTiJsf4qzghV1KeM",
+	"APe+Xz+tqkLqgENXYaoWKvWNQ870kKa1tahTvBcHiVDakdTpQOzenc5CWrZ3aivJa08qvc7cY8GRpHqg",
+	"Yn8+OzuB8CPE+MZ3lSZcIsdDkKJi4Gxvc2MJXF2W0jY7XoLfJbmtqbjbzdirNkx09++5g5J2kT9z5/gD",
+	"WPf05XP44WjyQ2cu0twYTluW5zyhzNhXD5ZQJnzEtFCoCaS7dIFUqqpQgaMO4n7ff3JG/+hXh5c3KANp",
+	"uWuoeAPAFaF2DKoSyzlaN+a4DYjbfrDZDzb7wea/abDpq8m+J9v3ZH/onowLG9PaKmrecmUECMlKvcLm",
+	"uKa8f7Q47XgclKEuory6pBuJKLemXubADdyIaWYMZ5GTPFE470MoW47ZluR5nuBZTbG1MNS1JT8V3bi3",
+	"cT0clsM5R2nRtscO31625PbL+zOx21P88v4MZJqi4wxeoh7DmwqtLxMHhXK0fUzKsYEY3NAn+HcgldYq",
+	"74brPAoU7Af4kUMuE/bsPBDVuYC0kKoce+bioItpPO3Gq5yoEuu17z4XZoiStFxiyYweERETE1Vp3uxm",
+	"p+u6Qg5f8dLjk5lIxBVaF3Y9HE/GEw6lqVDLSompeDqejJ+KRFSScg+OLqf8ZYleg0wbtlnGVKMcHfus",
+	"OP9iJAnn2/1dN1aqrEvQNfdS7Ip3wasId3UtDjzfbGDgyagNnwzRWci6IDF9MtlSNKXp6ZNwwcNmNtc7",
+	"8Vu/nV4nu0d8U0luZNLaOmNhYU0ZLx5+wxWNnofHAacb/sIrZWoHFVfdsAthvxs+7NbyRSIsuspoF8ry",
+	"yWQSNEoTagqFuuljuX/dXLHzp04g7hqxuquYXd1d71YLp5U9bIGWgLEZ2gA2X73MT7F/CAHx1l8rfTnA",
+	"qC+fw9GToyMolL4McxmCxhX5oIVuwWLxt3PBD89F18CYUFy+7YvxvT2EibiRp/4xYv6YzW4c4OvMscFn",
+	"vzNLdyUnzrzr5MYe2xPLw/dqh++BzM50uH6NmPROHD42J35VjvWWuxXV+mPRjx+ycMGpp4/NqecbD6CQ",
+	"6WV3wcAKH+SGHbamQPbwL48Re4RWywIc2iu0sbHxvjx7bL60XWKGMitYenGVImaY3Wi0vAxu9yofRCup",
+	"U5mVSosLVqHtJmxgxUUi4t1OS80bXo4zHxiNLt5K+7sEkku3vRkbEpVxNHzn3ooPt7QklQ5dW3sTP4Y3",
+	"fMcuIZcuZ11Q/uLdkbGYJeAMP0il1oZgzpuRVXjF0/lSKs3Nz82uIf7t5L0WoY1FR383WfPNYDD0z9b6",
+	"Zs9MtsZ1T3cPv/ER2j9PBjDU9tdtO+xq36By1BvxuOVF6aqmvbrs1WWvLo9KXQJngdStxAxLyTrZzIYH",
+	"X4KBWbYO2lIgYX9SPPVC1XH+naNiS4yzF+1ExSPpZqBqDYpdOt9u2O+5dh4YuZ7dfgXSyuz/EEV3Qdyz",
+	"9GNl6WePj9naguJW0V+hsz+ysCizrsr2AvTHFaAgE/cLkD8Fh3lIP17gFRam8lemYZVIRG2LeNk6PTgo",
+	"TCqL3DiaHk2OJqxlB1eHYn2x/vcA30Diw8YqAAA=",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	for rawPath, rawFunc := range externalRef0.PathToRawSpec(path.Join(path.Dir(pathToFile), "../../schemas/APIKey.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
+	for rawPath, rawFunc := range externalRef0.PathToRawSpec(path.Join(path.Dir(pathToFile), "../../schemas/CreateAPIKeyRequest.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
+	for rawPath, rawFunc := range externalRef0.PathToRawSpec(path.Join(path.Dir(pathToFile), "../../schemas/CreatedAPIKey.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
+	for rawPath, rawFunc := range externalRef0.PathToRawSpec(path.Join(path.Dir(pathToFile), "../../schemas/Error.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
+	for rawPath, rawFunc := range externalRef0.PathToRawSpec(path.Join(path.Dir(pathToFile), "../../schemas/Problem.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,3 @@
+package apikeys
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen -config ../../../api/specs/apikeys/cfg.yaml ../../../api/specs/apikeys/api.yaml
@@ -0,0 +1,346 @@
+// Package auth provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+package auth
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	externalRef0 "oapi-codegen-layout/pkg/api/models"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Defines values for TokenResponseTokenType.
+const (
+	Bearer TokenResponseTokenType = "Bearer"
+)
+
+// Error defines model for Error.
+type Error struct {
+	// Code Machine-readable error code, such as not_found
+	Code string `json:"code"`
+
+	// Details Per-field problems, e.g. for request validation failures
+	Details *[]ErrorDetail `json:"details,omitempty"`
+
+	// Field Request field the error refers to, when it concerns a single field
+	Field *string `json:"field,omitempty"`
+
+	// Message Human-readable description of the error
+	Message string `json:"message"`
+}
+
+// ErrorDetail defines model for .
+type ErrorDetail struct {
+	// Field Location of the problem, e.g. "name" for a body property or "limit" for a query parameter
+	Field string `json:"field"`
+
+	// Message Human-readable description of the problem
+	Message string `json:"message"`
+}
+
+// LoginRequest defines model for LoginRequest.
+type LoginRequest struct {
+	// Email Email address of the user
+	Email openapi_types.Email `json:"email"`
+
+	// Password Password of the user
+	Password string `json:"password"`
+}
+
+// Problem defines model for Problem.
+type Problem struct {
+	// Code Machine-readable error code, such as not_found
+	Code string `json:"code"`
+
+	// Detail Explanation specific to this occurrence of the problem
+	Detail *string `json:"detail,omitempty"`
+
+	// Details Per-field problems, e.g. for request validation failures
+	Details *[]ErrorDetail `json:"details,omitempty"`
+
+	// Field Request field the error refers to, when it concerns a single field
+	Field *string `json:"field,omitempty"`
+
+	// Instance URI reference of the request that caused the problem
+	Instance *string `json:"instance,omitempty"`
+
+	// Message Human-readable description of the error
+	Message string `json:"message"`
+
+	// Status HTTP status code
+	Status int `json:"status"`
+
+	// Title Short summary of the problem type
+	Title string `json:"title"`
+
+	// Type URI reference identifying the problem type
+	Type string `json:"type"`
+}
+
+// RefreshTokenRequest defines model for RefreshTokenRequest.
+type RefreshTokenRequest struct {
+	// RefreshToken Refresh token returned by the last login or refresh
+	RefreshToken string `json:"refreshToken"`
+}
+
+// TokenResponse defines model for TokenResponse.
+type TokenResponse struct {
+	// AccessToken JWT to send as bearer token. It carries the scopes of the user's role.
+	AccessToken string `json:"accessToken"`
+
+	// ExpiresIn Lifetime of the access token in seconds
+	ExpiresIn int32 `json:"expiresIn"`
+
+	// RefreshToken Single-use token that exchanges for a new token pair at /auth/refresh. Presenting it a second time revokes every token derived from the same login.
+	RefreshToken string `json:"refreshToken"`
+
+	// TokenType Type of the access token; always Bearer
+	TokenType TokenResponseTokenType `json:"tokenType"`
+}
+
+// TokenResponseTokenType Type of the access token; always Bearer
+type TokenResponseTokenType string
+
+// LoginJSONRequestBody defines body for Login for application/json ContentType.
+type LoginJSONRequestBody = LoginRequest
+
+// LogoutJSONRequestBody defines body for Logout for application/json ContentType.
+type LogoutJSONRequestBody = RefreshTokenRequest
+
+// RefreshTokenJSONRequestBody defines body for RefreshToken for application/json ContentType.
+type RefreshTokenJSONRequestBody = RefreshTokenRequest
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Exchange email and password for a token pair
+	// (POST /auth/login)
+	Login(c *gin.Context)
+	// Revoke a refresh token
+	// (POST /auth/logout)
+	Logout(c *gin.Context)
+	// Rotate a refresh token into a new token pair
+	// (POST /auth/refresh)
+	RefreshToken(c *gin.Context)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandler       func(*gin.Context, error, int)
+}
+
+type MiddlewareFunc func(c *gin.Context)
+
+// Login operation middleware
+func (siw *ServerInterfaceWrapper) Login(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.Login(c)
+}
+
+// Logout operation middleware
+func (siw *ServerInterfaceWrapper) Logout(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.Logout(c)
+}
+
+// RefreshToken operation middleware
+func (siw *ServerInterfaceWrapper) RefreshToken(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RefreshToken(c)
+}
+
+// GinServerOptions provides options for the Gin server.
+type GinServerOptions struct {
+	BaseURL      string
+	Middlewares  []MiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router gin.IRouter, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, GinServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.POST(options.BaseURL+"/auth/login", wrapper.Login)
+	router.POST(options.BaseURL+"/auth/logout", wrapper.Logout)
+	router.POST(options.BaseURL+"/auth/refresh", wrapper.RefreshToken)
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+xZWW/bSBL+K4XeBfZhaUlOsojBPCWbLOKFd8dwHMxDYgRtdpHsmOxmuoqyCUP/fdCH",
+	"ZB30MYMMZjzxk6U+6vzqq2rrWhS27axBwyTya0FFja0MH985Z53/0DnboWONYbmwCv1fhVQ43bG2RuTi",
+	"f7KotcE9h1LJ8wYB/W3whzOgvqhBEhjLX0rbGyUywUOHIhfETptKLDKhkKVuaFf0Mbq9UmOjoHP2vMGW",
+	"MsBJNYHSOnD4rUdimMtGK+lvQCl10zskkQnN2NKuC0HarqIjW0QJtgSucakuafssjGzxswhqJZxbNUAS",
+	"O4B18Fk0utW8OvCtRzdAJ51skdGNudwikaxGovm+b6W5ieXa5pZtu1IXmfAx0Q6VyD8lV29Una0u2POv",
+	"WLDIxNVeZff84p53UOQx8W9DOryV6bx0Tg7++y3RO0mZiKniegkBhyU6ArYZXNZoQDMU1hToDIEE0qZq",
+	"EJZmfs8QBfX3BijA+Y74LDJxZCttknu7YMLWx2nHvnd+GaRSDomWJvUUgFBa10oWebo74nYniS6tGwnz",
+	"cdrZEtnKqyM0Fdci3392kIlWm9X3+0KwtGKldCwIxwlw+bWQTfNTKfJP1+LvDkuRi79Nb0hkmhhkKjv9",
+	"JX3+EqlkkW3HLtb8SPCuukaaWIzUYaFLXQBb4FoT2KLonUNT4L3FkAltiKUpRgD08eQwYnNd0pJOuJYM",
+	"hewJ1X0aiCX3I7T1/vT0GOImJJClu9owVujjIVhzM2Lbh9o6BurbVrphy0sIUkYMiQt3u6kVGtbloE31",
+	"AJlbOFkeCjavHN8Fy1m2TQ3/+Te8PJi9XKlLXD+BE+TeGVTg84QyoDqAJXKFN7FoNBoGSRcUmbXrGh2J",
+	"eprk/fMrWfMqnI6Xb1AG0iFcYOcFAF4xGvKgarE9R0cTH7cTLB1SfWov8PYqd2uHxrgv7AL7bXBLp86H",
+	"YFMjiaHxJAKRD/1Z8etqdEP/WH0m86mzhnDXflkUSHSL+f/9+dRXF6FRPkznKB266MwEDn0hOOdj6Z2h",
+	"wna4QWj/IHC2wckYJvGq0w7pcETpkS6RdbsqvWhhCqE2QFhYo2idLrXh589G6+ju9HwITWavJ0ziQ3nj",
+	"VVFLU2GCFRi8TNud1A4kw1T2XE+T7AkcOyRfPqbyTUwmCyE44XBuL5AA577rRzEKnZ6jgtLZNsZOthiB",
+	"MBqscOt0tIr96ligXoFsLuVA8CbkTGQCTd96wKSFs/uAtQ6MdRPWc5fdD79dtn8aHJ8Gxx96cFyE8aO0",
+	"d8xwsSlIo9LwsdZFKJLUBWIHcnmw0fObDpyL1z3X8Pr4UGRijo6i8P3JbDLzEbAdGtlpkYvnk9nkeRjw",
+	"uA44jsQWhPqvnY0tz2MyAPlQBVT77eg0Er+xaohlbBhNOL/ein0LvnlAivzu4XBjpF5shpZdj2Eh9rJg",
+	"8LPZ7Lvp3uyUQflmev6/0Qh8KF98R/VpFl5kGzLWJ5mHy1oO5SNOHJrAbKBN13P0Yf+x+fDRXBh7aQA3",
+	"31IOLp01FazeK4tM/OsxZojRGdkAoZujS7QTfHnx2HxZcrhCqRpt0A9XiApVUE1Y9E7zIPJPZ5lIz5rw",
+	"zIsT2DLBRq1ymnrhWhlmgmVFYWTpuRZnXvCKx2zP60S2bVyczXY4Nmh86MQGr9fmLgLZ+BYygCbqUfmH",
+	"3hBnCegN68bfHiCOUH7Y26FWb/Hvw61j75kHUeyLscmmqlCB7f1rNPhf9k0zPHHiH86JG0B+YsBHyoCR",
+	"mkBupfN2rkvnbie70zDjh4cqqi2605TeqWrjMSuXq+mYrKQ2v+VFu0ly6zz0Z6O6p2nyh2XOLHVlFf8Z",
+	"F4H/xKZ/CTa1LHmHTUEbtjv/3xvh2CDZh47CjxubFr3FOTa2a9FwCrDIRO8akYuaucun08YWsqktcX4w",
+	"O5j53z+m832xOFv8MgArDt6UZB0AAA==",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	for rawPath, rawFunc := range externalRef0.PathToRawSpec(path.Join(path.Dir(pathToFile), "../../schemas/Error.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
+	for rawPath, rawFunc := range externalRef0.PathToRawSpec(path.Join(path.Dir(pathToFile), "../../schemas/LoginRequest.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
+	for rawPath, rawFunc := range externalRef0.PathToRawSpec(path.Join(path.Dir(pathToFile), "../../schemas/Problem.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
+	for rawPath, rawFunc := range externalRef0.PathToRawSpec(path.Join(path.Dir(pathToFile), "../../schemas/RefreshTokenRequest.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
+	for rawPath, rawFunc := range externalRef0.PathToRawSpec(path.Join(path.Dir(pathToFile), "../../schemas/TokenResponse.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,3 @@
+package auth
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen -config ../../../api/specs/auth/cfg.yaml ../../../api/specs/auth/api.yaml
@@ -19,17 +19,110 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// Defines values for HealthCheckStatus.
+const (
+	HealthCheckFailed HealthCheckStatus = "failed"
+	HealthCheckOK     HealthCheckStatus = "ok"
+)
+
+// Error defines model for Error.
+type Error struct {
+	// Code Machine-readable error code, such as not_found
+	Code string `json:"code"`
+
+	// Details Per-field problems, e.g. for request validation failures
+	Details *[]ErrorDetail `json:"details,omitempty"`
+
+	// Field Request field the error refers to, when it concerns a single field
+	Field *string `json:"field,omitempty"`
+
+	// Message Human-readable description of the error
+	Message string `json:"message"`
+}
+
+// ErrorDetail defines model for .
+type ErrorDetail struct {
+	// Field Location of the problem, e.g. "name" for a body property or "limit" for a query parameter
+	Field string `json:"field"`
+
+	// Message Human-readable description of the problem
+	Message string `json:"message"`
+}
+
 // HealthResponse defines model for HealthResponse.
 type HealthResponse struct {
-	Status    string    `json:"status"`
+	// Checks Results of the dependency checks, in registration order
+	Checks *[]HealthCheck `json:"checks,omitempty"`
+
+	// Status ok; degraded when a non-critical check failed; unavailable when a critical check failed; shutting_down while the server drains before exiting
+	Status string `json:"status"`
+
+	// Timestamp When the checks ran
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// HealthCheckStatus ok, or failed when the check returned an error or timed out
+type HealthCheckStatus string
+
+// HealthCheck Result of a single dependency check
+type HealthCheck struct {
+	// Critical Whether a failure of the check makes the service unavailable
+	Critical bool `json:"critical"`
+
+	// Error Generic reason the check failed; the error of the check is only logged, since it may reveal internal hosts and paths
+	Error *string `json:"error,omitempty"`
+
+	// LatencyMs Time the check took in milliseconds
+	LatencyMs float64 `json:"latencyMs"`
+
+	// Name Name of the check
+	Name string `json:"name"`
+
+	// Status ok, or failed when the check returned an error or timed out
+	Status HealthCheckStatus `json:"status"`
+}
+
+// Problem defines model for Problem.
+type Problem struct {
+	// Code Machine-readable error code, such as not_found
+	Code string `json:"code"`
+
+	// Detail Explanation specific to this occurrence of the problem
+	Detail *string `json:"detail,omitempty"`
+
+	// Details Per-field problems, e.g. for request validation failures
+	Details *[]ErrorDetail `json:"details,omitempty"`
+
+	// Field Request field the error refers to, when it concerns a single field
+	Field *string `json:"field,omitempty"`
+
+	// Instance URI reference of the request that caused the problem
+	Instance *string `json:"instance,omitempty"`
+
+	// Message Human-readable description of the error
+	Message string `json:"message"`
+
+	// Status HTTP status code
+	Status int `json:"status"`
+
+	// Title Short summary of the problem type
+	Title string `json:"title"`
+
+	// Type URI reference identifying the problem type
+	Type string `json:"type"`
+}
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Health check endpoint
 	// (GET /health)
 	GetHealth(c *gin.Context)
+	// Liveness probe
+	// (GET /health/live)
+	GetLiveness(c *gin.Context)
+	// Readiness probe
+	// (GET /health/ready)
+	GetReadiness(c *gin.Context)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -54,6 +147,32 @@ func (siw *ServerInterfaceWrapper) GetHealth(c *gin.Context) {
 	siw.Handler.GetHealth(c)
 }
 
+// GetLiveness operation middleware
+func (siw *ServerInterfaceWrapper) GetLiveness(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetLiveness(c)
+}
+
+// GetReadiness operation middleware
+func (siw *ServerInterfaceWrapper) GetReadiness(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetReadiness(c)
+}
+
 // GinServerOptions provides options for the Gin server.
 type GinServerOptions struct {
 	BaseURL      string
@@ -82,18 +201,39 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 	}
 
 	router.GET(options.BaseURL+"/health", wrapper.GetHealth)
+	router.GET(options.BaseURL+"/health/live", wrapper.GetLiveness)
+	router.GET(options.BaseURL+"/health/ready", wrapper.GetReadiness)
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/3xRy27cMAz8FWPao7tymkugW4ECbW5Fewz2oMrctRJLYiXuoovA/15QdvrYAjlpIHJI",
-	"zswzfI6cEyWpsM+ofqLoGvxMbpbpK1XOqZL+cMlMRQKtreLk1BD9dJFngkV+Qg+5sOIqJaQjlh4SIlVx",
-	"kbX5kEt0AovRCb3T0v+UpUehH6dQaIR9eNn096D9b07+/khesCgppEPWHSNVXwJLyAl2E9L5ifxTR2nk",
-	"HJK0adKO3uofvtyjx5lKXWk3u2E36PmZKTkOsLjdDbtb9GAnU1NupsZVeCTRRx1yuvh+hMUnknU6VNHq",
-	"ZCO+HwZ9fE5CqREd8xx8o5rHqhe8pKHobaEDLN6YP3GZLStzFVRz4l8HvlE5B09dqN168KV5XE8xunJ5",
-	"zSJ3rJrAJnPfZlcq6hLsw7XTH+lMc+ZISbq1Cz1OZYbFJMLWmDl7N0+5ir0b7gbjOJjzDZb98isAAP//",
-	"JYwD/YkCAAA=",
+	"H4sIAAAAAAAC/+xYW28buxH+KwO2b11dcoLgBHueDs6lMU7TGE6KPiSGMdod7TLmkhvOrGzB0H8vSO5K",
+	"K2kdB0XQBm2eTFnkXD5+881QD6pwTessWWGVPyguamowLn/z3vmwaL1ryYum+O/ClRT+lsSF161oZ1Wu",
+	"XmNRa0szT1jiyhBQOA1hcwbcFTUgg3Vys3adLVWmZNuSyhWL17ZSu0yVJKgNn5u+JD9bazIltN6tDDWc",
+	"Ac2rOaydB0+fOmKBDRpdYjgBa9Sm88QqU1qo4fMUorVzR39zRbLg1iA1De56bx+UxYY+qOgWYeXKLfRm",
+	"t+A8fFBGN1r2Gz515LfQoseGhPxUyg0xYzWB5quuQXvAcvTlSWznVneZCphoT6XK3/epHlxd7w+41Ucq",
+	"RGXqfla5WfjnLCSo8nTxv8brCFH2+9F73IbPj6B31d9E/DrGmCjgaU2eQVwGdzVZ0AKFswV5y4DA2laG",
+	"YAjza0IU3T8JUKTzZ/DZZeoVoZH6irh1lmmiImoqbnkKEe6M8BBPSS3ZkmyxhXQiA23BU6VZfE88X5If",
+	"83bKYLC3B+7UqMpOg/NadIHm3No/a5KaAlX7ihkCjYagwVvi+JnJb3RB0FncoDYB7wOsK+cMoQ040SAY",
+	"x37+Spa8LsATsrMjD8EtlT+NuHIUgGZw1mzBuKqiMgsZFxTo0+AWPG0IDWgr5C0aqB0LA9oSWpSap6hk",
+	"UAJOryeAfacbGnkW527D3TTaGM1UOFsGi3SPTWtI5c/mP7zI1Nr5BkXlqnTdESS2a1bkg8tUUKfe/o7N",
+	"MdZj26pEwRUyTaXAgtJNxO9usyBBCdBUZodsPEnnLZWAdoDZg+iGSnCdBN+2a0IpuBBIsqGuT70HnQgb",
+	"Zxv0IS0OJ1Jl/BLcvPlDZePPv+/tnOnLaNfblNBpUcaN+3SzA4nHt/glUjZyNSVlj+P5E5RUeSwHOBGs",
+	"s7MhjhMCjwpj2P3ITq47EW2rm9LdWbirtaF9iZGH0qO2DCtaO09A91oS9Ad2xDs640W4TRZs2skqH5GB",
+	"waNVY+6i0Cwcf1Io95dxcDYllpd9Y8ofFBrzZq3y9w/qz57WKld/WhyGjUU/aSyw1Tf9+iaNHLvsVGHT",
+	"bHCe3G/3rUGbtJNbKvRaFyAOpA7iURSd92SLfbE92jQzpS0L2mKiWv9xdZF62NjSMHZIjQIFdkzlUx4e",
+	"49qrd+8uIX0JfTPqz2orVCUhES1mIra3tfMC3DUN+u1JlhCtTJFl206YOk5Tl2RFr7faVl9g84Qpw6YY",
+	"8z7xc7JcZ6f97fdf4MeXyx/37vqZcA5Xg4aFeyIsQ66RLCOxM5qsAPItpwmsbY1OA92it/eXj+xs6jfp",
+	"8IFlgJ7gltpgAOheyHIgVUNBynkecDsn6vfZ+Pts/H89G++icq7dBF/0hiwxZxDC0GEZp7PRvFrH3tz3",
+	"pb1eDD0bfr68UJnakOdk8dl8OV+GtF1LFlutcvV8vpw/V5lKI1/+oBbJZlhWJBP30FmensXB6FuCiuRq",
+	"CHcOf1ArkbBJWjipfespNM1oxvRZxtQOiQYCEs9VjDWN9hdlnIQlJacCzuk5EeP+YblM8mGFbIx7rF5B",
+	"tQ5vc5V/vp+evFbiHZ10jX6e19zfQazRYd4JEL/4ivH0PX2XHdkYK/KX2xqGi4mkLoaXQD9KUT9KqBfL",
+	"5/9BbH8+TH4jjqXxL87o4weV5v1ACGEgjCj13fxQCGmCJFu2TlsJhYJVnL57sl+HUz3zF4GRj9OfWucH",
+	"HvdKWRBzCMR31oY4ApNjfLYaGgjP4UKgdBS7VB/PPjtNnAG78LpwnWC1n5GGh8zhpCcW9DLGYLJIBvH4",
+	"75bJ5QEcjLD+TxXGEdMGwJNyPUGxoHPbf0dik0gmCt71Pz6My6FAG9cE4nG91sUcwisyMPH88dX/ONCr",
+	"VrITB81JRu1V/ZtR3ohiBq1j1iuz/S6/35z8Xh3388mqCAdiwhzfucfB/EobMq5tyEoPi8pU543KVS3S",
+	"5ouFcQWa8NNV/nL5chmewovNM7W73v1rABB9rDeXFwAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -133,12 +273,24 @@ func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
 		res[pathToFile] = rawSpec
 	}
 
+	for rawPath, rawFunc := range externalRef0.PathToRawSpec(path.Join(path.Dir(pathToFile), "../../schemas/Error.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
 	for rawPath, rawFunc := range externalRef0.PathToRawSpec(path.Join(path.Dir(pathToFile), "../../schemas/HealthResponse.yaml")) {
 		if _, ok := res[rawPath]; ok {
 			// it is not possible to compare functions in golang, so always overwrite the old value
 		}
 		res[rawPath] = rawFunc
 	}
+	for rawPath, rawFunc := range externalRef0.PathToRawSpec(path.Join(path.Dir(pathToFile), "../../schemas/Problem.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
 	return res
 }
 
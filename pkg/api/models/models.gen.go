@@ -17,81 +17,403 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for CreateUserRequestRole.
+const (
+	CreateUserRequestRoleAdmin  CreateUserRequestRole = "admin"
+	CreateUserRequestRoleEditor CreateUserRequestRole = "editor"
+	CreateUserRequestRoleViewer CreateUserRequestRole = "viewer"
+)
+
+// Defines values for HealthCheckStatus.
+const (
+	HealthCheckFailed HealthCheckStatus = "failed"
+	HealthCheckOK     HealthCheckStatus = "ok"
+)
+
+// Defines values for Role.
+const (
+	RoleAdmin  Role = "admin"
+	RoleEditor Role = "editor"
+	RoleViewer Role = "viewer"
+)
+
+// Defines values for TokenResponseTokenType.
+const (
+	Bearer TokenResponseTokenType = "Bearer"
+)
+
+// Defines values for UpdateUserRequestRole.
+const (
+	UpdateUserRequestRoleAdmin  UpdateUserRequestRole = "admin"
+	UpdateUserRequestRoleEditor UpdateUserRequestRole = "editor"
+	UpdateUserRequestRoleViewer UpdateUserRequestRole = "viewer"
+)
+
+// Defines values for UserRole.
+const (
+	UserRoleAdmin  UserRole = "admin"
+	UserRoleEditor UserRole = "editor"
+	UserRoleViewer UserRole = "viewer"
+)
+
+// APIKey defines model for APIKey.
+type APIKey struct {
+	// CreatedAt When the key was created
+	CreatedAt time.Time `json:"createdAt"`
+
+	// ExpiresAt When the key stops working; absent for keys without expiry
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// Id Unique identifier of the key
+	Id openapi_types.UUID `json:"id"`
+
+	// LastUsedAt When the key last authenticated a request
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+
+	// Name Name that tells what the key is used for
+	Name string `json:"name"`
+
+	// Prefix Public part of the key, shown to tell keys apart
+	Prefix string `json:"prefix"`
+
+	// RevokedAt When the key was revoked; absent for active keys
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+
+	// Scopes Scopes granted to requests authenticated with the key
+	Scopes []string `json:"scopes"`
+}
+
+// CreateAPIKeyRequest defines model for CreateAPIKeyRequest.
+type CreateAPIKeyRequest struct {
+	// ExpiresAt When the key stops working; keys without expiry stay valid until revoked
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// Name Name that tells what the key is used for
+	Name string `json:"name"`
+
+	// Scopes Scopes to grant, such as products:read; at most 1000 characters when joined by spaces
+	Scopes []string `json:"scopes"`
+}
+
 // CreateProductRequest defines model for CreateProductRequest.
 type CreateProductRequest struct {
-	Category    string  `json:"category"`
+	// Category Category the product is listed under
+	Category string `json:"category"`
+
+	// Description Longer description of the product
 	Description *string `json:"description,omitempty"`
-	Name        string  `json:"name"`
-	Price       float64 `json:"price"`
-	Stock       *int32  `json:"stock,omitempty"`
+
+	// Name Display name of the product
+	Name string `json:"name"`
+
+	// Price Unit price of the product
+	Price float64 `json:"price"`
+
+	// Stock Number of units in stock
+	Stock *int32 `json:"stock,omitempty"`
 }
 
 // CreateUserRequest defines model for CreateUserRequest.
 type CreateUserRequest struct {
+	// Email Email address of the user, unique within the tenant
 	Email openapi_types.Email `json:"email"`
-	Name  string              `json:"name"`
+
+	// Name Display name of the user
+	Name string `json:"name"`
+
+	// Password Password for /auth/login. It must mix letters with digits or symbols and must not contain the local part of the email address.
+	Password *string `json:"password,omitempty"`
+
+	// Role Access level of a user. Editors may also change products; admins may also manage users and API keys.
+	Role *CreateUserRequestRole `json:"role,omitempty"`
+}
+
+// CreateUserRequestRole Access level of a user. Editors may also change products; admins may also manage users and API keys.
+type CreateUserRequestRole string
+
+// CreatedAPIKey defines model for CreatedAPIKey.
+type CreatedAPIKey struct {
+	// CreatedAt When the key was created
+	CreatedAt time.Time `json:"createdAt"`
+
+	// ExpiresAt When the key stops working; absent for keys without expiry
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// Id Unique identifier of the key
+	Id openapi_types.UUID `json:"id"`
+
+	// Key The full key to send in the X-API-Key header. It is only returned once.
+	Key string `json:"key"`
+
+	// LastUsedAt When the key last authenticated a request
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+
+	// Name Name that tells what the key is used for
+	Name string `json:"name"`
+
+	// Prefix Public part of the key, shown to tell keys apart
+	Prefix string `json:"prefix"`
+
+	// RevokedAt When the key was revoked; absent for active keys
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+
+	// Scopes Scopes granted to requests authenticated with the key
+	Scopes []string `json:"scopes"`
 }
 
 // Error defines model for Error.
 type Error struct {
-	Code    string `json:"code"`
+	// Code Machine-readable error code, such as not_found
+	Code string `json:"code"`
+
+	// Details Per-field problems, e.g. for request validation failures
+	Details *[]ErrorDetail `json:"details,omitempty"`
+
+	// Field Request field the error refers to, when it concerns a single field
+	Field *string `json:"field,omitempty"`
+
+	// Message Human-readable description of the error
+	Message string `json:"message"`
+}
+
+// ErrorDetail defines model for .
+type ErrorDetail struct {
+	// Field Location of the problem, e.g. "name" for a body property or "limit" for a query parameter
+	Field string `json:"field"`
+
+	// Message Human-readable description of the problem
 	Message string `json:"message"`
 }
 
 // HealthResponse defines model for HealthResponse.
 type HealthResponse struct {
-	Status    string    `json:"status"`
+	// Checks Results of the dependency checks, in registration order
+	Checks *[]HealthCheck `json:"checks,omitempty"`
+
+	// Status ok; degraded when a non-critical check failed; unavailable when a critical check failed; shutting_down while the server drains before exiting
+	Status string `json:"status"`
+
+	// Timestamp When the checks ran
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// HealthCheckStatus ok, or failed when the check returned an error or timed out
+type HealthCheckStatus string
+
+// HealthCheck Result of a single dependency check
+type HealthCheck struct {
+	// Critical Whether a failure of the check makes the service unavailable
+	Critical bool `json:"critical"`
+
+	// Error Generic reason the check failed; the error of the check is only logged, since it may reveal internal hosts and paths
+	Error *string `json:"error,omitempty"`
+
+	// LatencyMs Time the check took in milliseconds
+	LatencyMs float64 `json:"latencyMs"`
+
+	// Name Name of the check
+	Name string `json:"name"`
+
+	// Status ok, or failed when the check returned an error or timed out
+	Status HealthCheckStatus `json:"status"`
+}
+
+// LoginRequest defines model for LoginRequest.
+type LoginRequest struct {
+	// Email Email address of the user
+	Email openapi_types.Email `json:"email"`
+
+	// Password Password of the user
+	Password string `json:"password"`
+}
+
+// Problem defines model for Problem.
+type Problem struct {
+	// Code Machine-readable error code, such as not_found
+	Code string `json:"code"`
+
+	// Detail Explanation specific to this occurrence of the problem
+	Detail *string `json:"detail,omitempty"`
+
+	// Details Per-field problems, e.g. for request validation failures
+	Details *[]ErrorDetail `json:"details,omitempty"`
+
+	// Field Request field the error refers to, when it concerns a single field
+	Field *string `json:"field,omitempty"`
+
+	// Instance URI reference of the request that caused the problem
+	Instance *string `json:"instance,omitempty"`
+
+	// Message Human-readable description of the error
+	Message string `json:"message"`
+
+	// Status HTTP status code
+	Status int `json:"status"`
+
+	// Title Short summary of the problem type
+	Title string `json:"title"`
+
+	// Type URI reference identifying the problem type
+	Type string `json:"type"`
+}
+
 // Product defines model for Product.
 type Product struct {
-	Category    string             `json:"category"`
-	CreatedAt   time.Time          `json:"createdAt"`
-	Description *string            `json:"description,omitempty"`
-	Id          openapi_types.UUID `json:"id"`
-	Name        string             `json:"name"`
-	Price       float64            `json:"price"`
-	Stock       *int32             `json:"stock,omitempty"`
-	UpdatedAt   *time.Time         `json:"updatedAt,omitempty"`
+	// Category Category the product is listed under
+	Category string `json:"category"`
+
+	// CreatedAt When the product was created
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Description Longer description of the product
+	Description *string `json:"description,omitempty"`
+
+	// Id Unique identifier of the product
+	Id openapi_types.UUID `json:"id"`
+
+	// Name Display name of the product
+	Name string `json:"name"`
+
+	// Price Unit price of the product
+	Price float64 `json:"price"`
+
+	// Stock Number of units in stock
+	Stock *int32 `json:"stock,omitempty"`
+
+	// UpdatedAt When the product was last changed
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// RefreshTokenRequest defines model for RefreshTokenRequest.
+type RefreshTokenRequest struct {
+	// RefreshToken Refresh token returned by the last login or refresh
+	RefreshToken string `json:"refreshToken"`
 }
 
+// Role Access level of a user. Editors may also change products; admins may also manage users and API keys.
+type Role string
+
+// TokenResponse defines model for TokenResponse.
+type TokenResponse struct {
+	// AccessToken JWT to send as bearer token. It carries the scopes of the user's role.
+	AccessToken string `json:"accessToken"`
+
+	// ExpiresIn Lifetime of the access token in seconds
+	ExpiresIn int32 `json:"expiresIn"`
+
+	// RefreshToken Single-use token that exchanges for a new token pair at /auth/refresh. Presenting it a second time revokes every token derived from the same login.
+	RefreshToken string `json:"refreshToken"`
+
+	// TokenType Type of the access token; always Bearer
+	TokenType TokenResponseTokenType `json:"tokenType"`
+}
+
+// TokenResponseTokenType Type of the access token; always Bearer
+type TokenResponseTokenType string
+
 // UpdateProductRequest defines model for UpdateProductRequest.
 type UpdateProductRequest struct {
-	Category    *string  `json:"category,omitempty"`
-	Description *string  `json:"description,omitempty"`
-	Name        *string  `json:"name,omitempty"`
-	Price       *float64 `json:"price,omitempty"`
-	Stock       *int32   `json:"stock,omitempty"`
+	// Category New category of the product
+	Category *string `json:"category,omitempty"`
+
+	// Description New description of the product
+	Description *string `json:"description,omitempty"`
+
+	// Name New display name of the product
+	Name *string `json:"name,omitempty"`
+
+	// Price New unit price of the product
+	Price *float64 `json:"price,omitempty"`
+
+	// Stock New number of units in stock
+	Stock *int32 `json:"stock,omitempty"`
 }
 
 // UpdateUserRequest defines model for UpdateUserRequest.
 type UpdateUserRequest struct {
+	// Email New email address of the user, unique within the tenant
 	Email *openapi_types.Email `json:"email,omitempty"`
-	Name  *string              `json:"name,omitempty"`
+
+	// Name New display name of the user
+	Name *string `json:"name,omitempty"`
+
+	// Password Password for /auth/login. It must mix letters with digits or symbols and must not contain the local part of the email address.
+	Password *string `json:"password,omitempty"`
+
+	// Role Access level of a user. Editors may also change products; admins may also manage users and API keys.
+	Role *UpdateUserRequestRole `json:"role,omitempty"`
 }
 
+// UpdateUserRequestRole Access level of a user. Editors may also change products; admins may also manage users and API keys.
+type UpdateUserRequestRole string
+
 // User defines model for User.
 type User struct {
-	CreatedAt time.Time           `json:"createdAt"`
-	Email     openapi_types.Email `json:"email"`
-	Id        openapi_types.UUID  `json:"id"`
-	Name      string              `json:"name"`
-	UpdatedAt *time.Time          `json:"updatedAt,omitempty"`
+	// CreatedAt When the user was created
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Email Email address of the user, unique within the tenant
+	Email openapi_types.Email `json:"email"`
+
+	// Id Unique identifier of the user
+	Id openapi_types.UUID `json:"id"`
+
+	// Name Display name of the user
+	Name string `json:"name"`
+
+	// Role Access level of a user. Editors may also change products; admins may also manage users and API keys.
+	Role UserRole `json:"role"`
+
+	// UpdatedAt When the user was last changed
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
 }
 
+// UserRole Access level of a user. Editors may also change products; admins may also manage users and API keys.
+type UserRole string
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xUTWsbMRD9K2bOW7NJbnsLpdBCCyElp9LDeDX2qll9ZDQqCcb/vUjr+Gtlx6aF9tCb",
-	"vZr35ulp3iyhdcY7S1YCNEsIbUcG88/3TCh0x07FVu7pKVKQ9N2z88SiKVe1KLRw/JJ+G3z+THYhHTRX",
-	"dV2B0XbzvwJ58QQNBGFtF7CqQFFoWXvRzo7RdQFg0dBB5fUZfTzrNuPmjg0KNKBcnPUEGalNNNBs29lo",
-	"ZsQJF8S1jwmnaI6xl1y14dBWbq7LFNoKLRLHqgKmp6iZFDTfBv2veqqtdd83SDf7Qa2k5oP7D4H4qPVk",
-	"UPd79xq+nOfc2y90oP6VPHOVJH9gdlyYEKdy75EoQyHgonR20DkzbOtLvT8S9tLdU/DOBhqLCIISB9ee",
-	"0fg+ox9LVok2FASN358YFHqXjuAtm9addolKgte5Oh2okbg2T4W6lXPFjVI2OtdqjytGrU5N0D+Urgqi",
-	"V5fZcfBW+arHUrnrdukFH3Lz//txefFOPGLl3152Y1mBSvvs8gxeIP534/hHIrG36k/nIOG1nbshyTsD",
-	"C187ZFIThYIT4xT1YTJ3PJGOJrd3n/KClLyH14Vfcg1U8JM4DBRX03pap1s5Txa9hgZupvX0JsUVpQvQ",
-	"2Nj3q18BAAD//6syoprFCAAA",
+	"H4sIAAAAAAAC/+xabXPbNhL+Kzu4ztxLKdlJ56Y95ZMvba++No3HcaY3k+QyK3IlIgIBBgAl6zr+7zcL",
+	"kBRNUpbipJl78TeJAHeB3Wd3Hyz4q0hNURpN2jsx+1W4NKcCw8+zi/Mfacu/SmtKsl5SeJ5aQk/Zmec/",
+	"GbnUytJLo8VM/JKTBp8TrGgLG3RQzxWJWBhboBczkaGniZcFiUT4bUliJpy3Ui/FTSLoupSW3EHZzpvS",
+	"wcbYldTLJ4BzR9rDwlgedbCRPjeVhyBue7R2mQ3VvtTyfUUgM9JeLiRZMItmGV3BVSWzMZkKnX/pjjAX",
+	"TwSsfM6KUrYaIFh6X5HzR+9AY0FDPT9jQeBz9OBJKQeb8LPWKx1UjjI23pjE0tJCXg9lXlRzJVMo0fqO",
+	"RRJwudlo8Caoit5AnjQm29LarI5EUj33lq8x9XIdprijLeRSU5IbKnwRnsPSombTe9PY3vWcwtjqAEB6",
+	"KoK4gab6AVqLW3ETtvu+kpYyMXslAliCt1oTt2tLOiH2ppVj5u8o9Sz4aRiN4XlZA2QQpfeMpJHwAedx",
+	"C2tUMoNKe6kaX3w+VBZ4/RPppc/F7NHpaSIKqdv/H+5jb6KbE3BVmgM6KK3JqtS7mSVkiHkojPPw6PT0",
+	"FNIcLaaeLK+QNLwzUlMG8y24ElNyXQyU6D1ZVvXPVzj51+nkL28nb778w6zz549/+mLMRIXU51HKowPI",
+	"qUFT73E/Pi7invYChNG8NHY7NNPTeiQ4ozYNO0RJxwFQ6Yzu4ZRbOvoqfzJ6SRY6D5ukUusf6Ds9Gmff",
+	"Slcq3AKP3in18RG7KK1MabRKeAhjQw27GDHVXJEIOmRRFWK224SuijnZAF5v0lXUsMBK+TCrFzlhMmuq",
+	"tPQOpIb4VkeZ1P6rx+O6pPa0JLsPWXGLyQ4g+zH20pHdn4EKlGpoqu/4MWCWWXKusVblyCZQxVrL6UfG",
+	"DOVJo75lxCj1o5zPyj4cvyU6tzF2hCJc1COhJp1wtThRZin1FM49FJXzUMhrUORjEuH6kcklO85YcNti",
+	"bpQD1Fmcq42H1GiPtQmUSVHdqrPUteC0t5PH39zeyeP+VhKxsdLTc622YuZtRYwCo4LtvrC0EDPxu5Md",
+	"KTypGeHJJc/pQ6bxRjD+fpxkOy6JSj1fiNmru5VhKd/Wv9/W794kfYCtaCR5XeUEiypSD870jnQGtSn/",
+	"MTm7OJ/8SFvICTOywUHSgdFqC5Z8ZTmzG53SdIiw3s5Z+XDDb/qhegaaNmrbEGE4uziPPEnqVFWZ1Etg",
+	"IDhKLQWDfWetsSPZ2mQj6H6GaS41Tbhs4VwREL8NPHlX3LTxbxem0qP0NCOPUo3Uyguyk4UklXEmmysq",
+	"XAI0XU4DyGtqFEkB8huwQKkq2yuHt7YQpI2l/hR7CZ/V1dpeB2i9FkEtwtxkW6jFbjl8XgslC+nbCe8r",
+	"sluOFizI0yijLcg5XI5Y84eqQL2z5Xgx4rUdBEfc6k7VACiJuJ4szYQfTmLiio7/NrhjyB6Tfdarsy+E",
+	"4bDGCAFLC7JMdZLIWmRIKilZ7QDBSb1UBM0yP6WJgvqDBgpwvsM+N4n4gVD5/JJcabSjkYjIKV25MYu4",
+	"Svm2smRUks5Ip1uIb3DogaWldN7WwLOR0LS4HRPI8lrD9YWKpL84K/m8oEbJt8+JoVpHTLPQIAgKXJEL",
+	"/x3ZtUwJKo1rlAojdajNNDdGEWq2EzUJ47aev5EmK1OwhM7ojgZWy+eoHVZuLaBJh8osl5QlvOOUGD4F",
+	"co5cEyqQ2pPVqCA34XykMyjR5278/OvZTs9GDHslC+po9sas2DeFVEo6So3OWCJdY1FyeXo0ffznMTY1",
+	"YFB3HDe6W+3KFhl6nKMbPzJ69NXI+s0q4RQUDRrDbLebtpygbsxswcuCC0zlWbdmVvZKmEDbggzxpq+d",
+	"8wRPnKzR8rYcvxEj4ymref6jSLr/v2/lDPJLZ9aLuKF9J4s4muxA3PXiMamso2osle235xPIaGkxa8yJ",
+	"oI2eNOvoAbgTGM3sPTNdXnkv9fJtxr2JTS4VtSFGFjKLUjuY08JYArqWPpp+h47go+EBXxbkPBblHUfs",
+	"mHPAoj7ytNzzSeuMnbKxZPkT881PxsSP4ttH8OH9fLvPUg+ZoVlFq3TMCBd1db4Xy4y8a0gyI0EaMd51",
+	"qVDHAuJKSuVCpqHtlXMGTdPKWtK3joPjzCERUjuPevRUeXkeC3lXUsO9QuckxdAlOaBhX8D9cHV1AXEQ",
+	"6orcPyIy8LwaWduL3FgPrioKtNveLiFISfa1xO7eZt1p3TI1Piyzh5NmUlhzu/EjePrl90/h629Ov27V",
+	"1cR4CpdNImc/EQZUB7B0Mr6SpD2gW7lIQ8tSychqT2p5X75zRseiG1/eoQzQEqyoZAFA1560Y1AVxPXM",
+	"TWtkh2bCp2/iDFx0THe/EXefDv9Ht4E+rms/0pbZ17m/Tzfpf7tflIiqzD4IHeFSI81RL4+GyJ2N8l5n",
+	"6lCn/JIWllx+ZVa0vzrazqQx9h9GwfPwjtXNY2CF/YVmD8RDF88VH1bbbukf3YUZS8BnacplW9GaVDyc",
+	"cJ2dwneZ9Ma6wNdROVObv+1xPwHMCqk7EwrUuIxlOpL5ukkReksNTV1L2oSMQUG+SEQQM6SsN4mo7b3v",
+	"5IZh5Xvs/fdfrtq2DTIpQ0s2Wj+0a1K0VjZHpNjP79CM3zuwRo21b9q7xfOxvCMXxGhsRMUV1j7naGlP",
+	"I4N4GcbI3Xh6EU6Qk8pRLT7UcbqOXqrrBzeN6uESpQX0dV+xlj2FC0uOtI/9I8B6heGAUV/POKA12W0t",
+	"JiMr15TBwpoi2o7zV2xTjlZqfutqtFzz0zFDPQFUG9w6+GvwWQc79YM3hyKhC4zuErq+Sw7Hy8uQpO5/",
+	"//EzbaAZPXQN8XHXHqzpN7nzCII/w70H66l+k1rW16I/3Z3HHsDc5zKDV0af9UJjn2cfLjXuc6nRPQG2",
+	"FxxDfLBt7/UdDHvlfh/CfN7Lsw/i0P0WwccT6FriQML9XHgcTW1d84k46q2rsHrph/jpyDXXw+dWD59b",
+	"PXxu9Z/0udWwS/hwO/twO/t/fjs7qLr/Pf2Jm9BzX5ixnjZayoDvAqEwGal4HGfDnV2ct33lduKzMEck",
+	"Yk3WRRGPpqfTUzaQKUljKcVMfDU9nX4V7i587sRMV0rd/HsAEHtlaXctAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -22,40 +22,126 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+const (
+	ApiKeyAuthScopes = "apiKeyAuth.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
 // CreateProductRequest defines model for CreateProductRequest.
 type CreateProductRequest struct {
-	Category    string  `json:"category"`
+	// Category Category the product is listed under
+	Category string `json:"category"`
+
+	// Description Longer description of the product
 	Description *string `json:"description,omitempty"`
-	Name        string  `json:"name"`
-	Price       float64 `json:"price"`
-	Stock       *int32  `json:"stock,omitempty"`
+
+	// Name Display name of the product
+	Name string `json:"name"`
+
+	// Price Unit price of the product
+	Price float64 `json:"price"`
+
+	// Stock Number of units in stock
+	Stock *int32 `json:"stock,omitempty"`
 }
 
 // Error defines model for Error.
 type Error struct {
-	Code    string `json:"code"`
+	// Code Machine-readable error code, such as not_found
+	Code string `json:"code"`
+
+	// Details Per-field problems, e.g. for request validation failures
+	Details *[]ErrorDetail `json:"details,omitempty"`
+
+	// Field Request field the error refers to, when it concerns a single field
+	Field *string `json:"field,omitempty"`
+
+	// Message Human-readable description of the error
 	Message string `json:"message"`
 }
 
+// ErrorDetail defines model for .
+type ErrorDetail struct {
+	// Field Location of the problem, e.g. "name" for a body property or "limit" for a query parameter
+	Field string `json:"field"`
+
+	// Message Human-readable description of the problem
+	Message string `json:"message"`
+}
+
+// Problem defines model for Problem.
+type Problem struct {
+	// Code Machine-readable error code, such as not_found
+	Code string `json:"code"`
+
+	// Detail Explanation specific to this occurrence of the problem
+	Detail *string `json:"detail,omitempty"`
+
+	// Details Per-field problems, e.g. for request validation failures
+	Details *[]ErrorDetail `json:"details,omitempty"`
+
+	// Field Request field the error refers to, when it concerns a single field
+	Field *string `json:"field,omitempty"`
+
+	// Instance URI reference of the request that caused the problem
+	Instance *string `json:"instance,omitempty"`
+
+	// Message Human-readable description of the error
+	Message string `json:"message"`
+
+	// Status HTTP status code
+	Status int `json:"status"`
+
+	// Title Short summary of the problem type
+	Title string `json:"title"`
+
+	// Type URI reference identifying the problem type
+	Type string `json:"type"`
+}
+
 // Product defines model for Product.
 type Product struct {
-	Category    string             `json:"category"`
-	CreatedAt   time.Time          `json:"createdAt"`
-	Description *string            `json:"description,omitempty"`
-	Id          openapi_types.UUID `json:"id"`
-	Name        string             `json:"name"`
-	Price       float64            `json:"price"`
-	Stock       *int32             `json:"stock,omitempty"`
-	UpdatedAt   *time.Time         `json:"updatedAt,omitempty"`
+	// Category Category the product is listed under
+	Category string `json:"category"`
+
+	// CreatedAt When the product was created
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Description Longer description of the product
+	Description *string `json:"description,omitempty"`
+
+	// Id Unique identifier of the product
+	Id openapi_types.UUID `json:"id"`
+
+	// Name Display name of the product
+	Name string `json:"name"`
+
+	// Price Unit price of the product
+	Price float64 `json:"price"`
+
+	// Stock Number of units in stock
+	Stock *int32 `json:"stock,omitempty"`
+
+	// UpdatedAt When the product was last changed
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
 }
 
 // UpdateProductRequest defines model for UpdateProductRequest.
 type UpdateProductRequest struct {
-	Category    *string  `json:"category,omitempty"`
-	Description *string  `json:"description,omitempty"`
-	Name        *string  `json:"name,omitempty"`
-	Price       *float64 `json:"price,omitempty"`
-	Stock       *int32   `json:"stock,omitempty"`
+	// Category New category of the product
+	Category *string `json:"category,omitempty"`
+
+	// Description New description of the product
+	Description *string `json:"description,omitempty"`
+
+	// Name New display name of the product
+	Name *string `json:"name,omitempty"`
+
+	// Price New unit price of the product
+	Price *float64 `json:"price,omitempty"`
+
+	// Stock New number of units in stock
+	Stock *int32 `json:"stock,omitempty"`
 }
 
 // ListProductsParams defines parameters for ListProducts.
@@ -65,6 +151,9 @@ type ListProductsParams struct {
 
 	// Limit Maximum number of products to return
 	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor from the X-Next-Cursor header of the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
 }
 
 // CreateProductJSONRequestBody defines body for CreateProduct for application/json ContentType.
@@ -106,6 +195,10 @@ func (siw *ServerInterfaceWrapper) ListProducts(c *gin.Context) {
 
 	var err error
 
+	c.Set(BearerAuthScopes, []string{"products:read"})
+
+	c.Set(ApiKeyAuthScopes, []string{"products:read"})
+
 	// Parameter object where we will unmarshal all parameters from the context
 	var params ListProductsParams
 
@@ -125,6 +218,14 @@ func (siw *ServerInterfaceWrapper) ListProducts(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -138,6 +239,10 @@ func (siw *ServerInterfaceWrapper) ListProducts(c *gin.Context) {
 // CreateProduct operation middleware
 func (siw *ServerInterfaceWrapper) CreateProduct(c *gin.Context) {
 
+	c.Set(BearerAuthScopes, []string{"products:write"})
+
+	c.Set(ApiKeyAuthScopes, []string{"products:write"})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -162,6 +267,10 @@ func (siw *ServerInterfaceWrapper) DeleteProduct(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{"products:write"})
+
+	c.Set(ApiKeyAuthScopes, []string{"products:write"})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -186,6 +295,10 @@ func (siw *ServerInterfaceWrapper) GetProductById(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{"products:read"})
+
+	c.Set(ApiKeyAuthScopes, []string{"products:read"})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -210,6 +323,10 @@ func (siw *ServerInterfaceWrapper) UpdateProduct(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{"products:write"})
+
+	c.Set(ApiKeyAuthScopes, []string{"products:write"})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -257,21 +374,39 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xXTW/cNhD9K8K0R9WSHRcIdEvqNlggBYICPQU+0OLsLlN+mRxuszD2vxckpdV+0OsY",
-	"cI09+CZRM5zhvDdvqAfojbJGoyYP3QP4fomKpcffHDLCL87w0NNfeB/QU1y3zlh0JDBZ9YxwYdw6Piv2",
-	"/TPqBS2hu2zbGpTQ2/caaG0ROvDkhF7ApgaOvnfCkjD62LstOGim8MDy6gfiWCf65Dc3TjGCDrgJdxIh",
-	"eQoVFHRTOB3UHbro58n0/0Q/jnMWJCWr7R5C07ur8hZCEy7iHpsaHN4H4ZBD9zXnP+ZTT6W73Xqau2/Y",
-	"Uwz+u3PGFcpteDrK0SEVes8WpW8HSaQdJvtS7AHz02AfZdAnvvAPtF9qRvgLiXTwpxhw9F3wvb1CEBxO",
-	"8OKMkK8hWP68chzglI76GGN2q11C8O8U/K13H57drweljEtCz00mw86ZxyapFNNsgQo1Vai5NSIqaQ0k",
-	"SOJk5qsPX2ZQwwqdz/6XF+1FG1M1FjWzAjp4d9FevItwM1omgBo7eMeXBSYII4As5jDj0MFn4WkMkTwd",
-	"U0joPHRfDzP+Q0hCV417VnfraodQIprcB0wvGa1dvuXBUJSXwzh/su+xwlVGozLzKSSZyiEFpx8JKIUS",
-	"tBdt24FXxRbMoSbODm8FaG9je3lrtM/kv2rbLKiaUKfKMmul6FNtm28+E3tKRBCq5Pizwzl08FMzTc5m",
-	"GJvNqJwTkZhzbJ15tF+liNxubaLPr8/M6VQqeYAUAs80odNMVh7dCl2Fg2ENPijFohzk5JiUU3Y1EFtE",
-	"VsF26Tb2p/EFVu5dHSDrGnr6aPj6xc5XvJ5s9lWUXMDNEe6XL5bDFu7jKo/yMOh05UPfo/fzIOU6Qn39",
-	"OlCvmBS8EtoGOiuCZfQqVmn8dyRZmWObepLB5mF4mvFNFgeJhMf8u0nrE/9OyuKI1OxmFKWov5MmbUPC",
-	"IbV2deqJS0pBfq4fnyn5XCXSXP//8I1JaEPV3ATNz4o4GdqKnSZNXZ6Wn3Aclh/XCc+zJEb7mvrEkZiQ",
-	"/o1diV2fkCZqxQtSwr48+UKBYHv37jPi18uP3+Ifxg+N31el9/AXdibj963Dhg55Sr+TT9qk1Dg3uEJp",
-	"bPrnylZQQ3ASOlgS2a5ppOmZXBpP3fv2fdswK5rVJWxuN/8FAAD//z6h/HTwEgAA",
+	"H4sIAAAAAAAC/+xabW/bthb+Kwe899uVHTftxQIP90OWrJu3rg26DB3QBAUjHVtcKFIlD5MIgf/7BUm9",
+	"2JbiZF3aLZu/2RLJw/PC53kO7VuW6qLUChVZNr1lNs2x4OHjkUFOeGJ05lJ6ix8dWvLPS6NLNCQwjEo5",
+	"4UKbyn/O0KZGlCS0YlN2VL8ByhHKuAwIC1JYwgycytCwhBX85hWqBeVs+mwySVghVPs9YVSVyKbMkhFq",
+	"wZbJuo1Nk6+0WqCBlYeg56v2e/YmAyYUL7C/9rGwpeQV+LdbV91/gBelEemAjV+UIAjv+hbm2hSc2JRl",
+	"2l1IZMGGKFzBpp0TyhUXaLwFSzq9jBbm3EkKo9atvQ6DvSWnBFkQCuKsFWNC0fP9YVtCES68sWXCDH50",
+	"wmDGpu9j+BoXk65AztuZ+uI3TMnv8ltjtBkoKp0NROcnnuZC4cggz/iFREA/G/zgBKxLc+AWlKYPc+1U",
+	"xgaLh7iQtr/0CZrRXKDMfMAvJBY2ARwvxjDXBkysfbjiUmQ8VNWcC+kMWpYwQVjYvgthtaEKTflGXXpz",
+	"tbWzELwzFsxyuNBZBfWyFWgDZ0yKQlA74KNDU0HJDS+Q0Ay5XKC1fDEQze9dwVUXy+Ez4/fWX3Uj4dHV",
+	"zlQv0Qm7GS30yD8cxcMVE38c0uF3WY/nxvDKf78jejUKQUwV5U0JGJyjsUA6gescFQiCVKsUjbLAwQq1",
+	"kAjNNh8zRMH8vQEK5bwlPsuEndSxnt4yLuWbOZu+v2X/NjhnU/avvQ6j92qA3uOl+FB//hBP0TLZrMFY",
+	"7n2/vr0pJVexDm2JqZiLFEgD5cKCTlNnDKo1DBqug4QJZYmrQSh7O4tpWV2pOUmUc4KUO4vZfRYscXID",
+	"J/b709MTiC+hju8mLiWMBMmBvf2ca0NgXVFwU214CWGVgY3EB9vdFBkqEvNKqMUD1tyokmZQ2HPreL9Y",
+	"zjdx/O3LI/jqYPJVa66GuTG8RXJGYQY+T8gz72solnhM/BZTKVARcHtpI6iUpRQRo/bq9f7zm9Xq6zA6",
+	"Tu6qDLhBuMTSLwB4Q6isL6oCPbPYcV3ZgcEeXzn0UpQGxZIdUn/Jd42/zXLX3EI9fo1cOeGIRIHsc2iP",
+	"/vnJBkXAR9fWkkDTX6fdrnNiENI+RcL8vUVKwlyZ/a7qkNwSpDlXiweXyMaJDrm5Sw6tlusQI/wStvvp",
+	"8vs1XkPz9j4V/MdUt7f0WSR3WPgLyG5vx32Wqt60oh5PcvcKpq8Idrp6p6v/0braH0ZMnRFU/exPRiwh",
+	"XoofsTp0lPe3dngyg0usQh0U8VzUEskmjV4Ayo12ixy8CB9dYmXHcJpjmLcwXJENPthUl2hBRDZp5l4L",
+	"yse+vL21HHmUMnX2fh0dnsxGP2LVuR4368N5gdygabYdv71sQOOHd6dskzp/eHcKPE3R+gxeohrDmxJN",
+	"OCZRS61uk3KsoA5u1HphDqTcGBHcsK1HkSttyVMcWfTHxHt2xsL7Mwap5KLwTgY48l7E3XZe5UQlWy5D",
+	"BzHXA7hR83DBFV9ggYoAVVZqoci2GrkdZuHwZMYSdoXGxvnPxpPxxAdNl6h4KdiUPR9Pxs9ZwkpOeSiD",
+	"vRrgw5cFBnLVTYBmmQcVYakxEWbWeGBDd7a+45dCEpqGNCxcVLBC9SHbAVW6ZK+8jrDtN9Ar+D5Y33hW",
+	"WGGS1iRpMEHw32EwYNyatVZ27U+GCCia6hRC/W2Ijja3+abkXsSmzlhtYG50EWrm19FrvKHRUXwcy7+D",
+	"RbwS2lko/WG+I2Zh4taInSfMoC21svG0708mkfoUoaJ4/rsWx7c23b2n/9Tyzrbuu2lqehC73BRLoYhW",
+	"05SANhkazEKJeFTwuFfryRiRYP6VUAMiwvd6B/sHByCFuow9O4LCGwpRC+gCBuX/zph/eMa+Bn5h/fHR",
+	"8dAGUVsHeEvVsbVEDXRodV61Wd/Ap5nzBl/8zjRty059H7JM1tZY7WYfvlZzMTOQ2ZkKyqUuchacePbU",
+	"nPhJWM/jXgWJxh+Doffk0kannj81p446D0Dy9LK9fPLKIdKYd9hoid7D/z7F2iM0ikuwaK7Q1IIp+PLi",
+	"qfnSqM8MeSa94MKbFDHDbE3ABc5d1UDvWQOpUy8l2blnoVVt1x9wnrD61q8BZi5li8wsYcQXdnWi15Q3",
+	"odnXjryumIQjUWo7IBfWfjBjUaqipW90Vj1aSgZ/lFuuC2MyDpc9Fnw8ZGrJr5/K+lWrdq0L+nPupKzY",
+	"00Z5oUpHO5DfgfwO5P8ckL82gnAryjcj1mA+IiZwUHi9etvdQ3q/j7Yn3LutP82yZZS/Egn7mH8cnneY",
+	"v7VHrIfB7Lhpb3wz2nU3rUm2Ceer6vmey/+BBujF3Q129OtvBdRdFHdg/VTB+sXTA7jmRClNEC/Kd6Tz",
+	"jyWdSAvAtxNOMnzt+B02t47fVLPsr0oqky/ZTzQ/MO2YacdMO2baMdOOmT7tzus7pI6U/A8Qs+O7qKl0",
+	"A9S09qeUvxAzPf5F2+Dfbx500fZFibH+U9Puom3HkDuG3DHkjiH/eO8Wkf++3i1uwkd5iPqO8QqlLsMf",
+	"V+IoljBnZP3Xl+nentQpl7m2ND2YHEz8P4n2rp6x5fny/wMAN2QzeZI1AAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -323,6 +458,12 @@ func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
 		}
 		res[rawPath] = rawFunc
 	}
+	for rawPath, rawFunc := range externalRef0.PathToRawSpec(path.Join(path.Dir(pathToFile), "../../schemas/Problem.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
 	for rawPath, rawFunc := range externalRef0.PathToRawSpec(path.Join(path.Dir(pathToFile), "../../schemas/Product.yaml")) {
 		if _, ok := res[rawPath]; ok {
 			// it is not possible to compare functions in golang, so always overwrite the old value
@@ -22,37 +22,163 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+const (
+	ApiKeyAuthScopes = "apiKeyAuth.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for CreateUserRequestRole.
+const (
+	CreateUserRequestRoleAdmin  CreateUserRequestRole = "admin"
+	CreateUserRequestRoleEditor CreateUserRequestRole = "editor"
+	CreateUserRequestRoleViewer CreateUserRequestRole = "viewer"
+)
+
+// Defines values for Role.
+const (
+	RoleAdmin  Role = "admin"
+	RoleEditor Role = "editor"
+	RoleViewer Role = "viewer"
+)
+
+// Defines values for UpdateUserRequestRole.
+const (
+	UpdateUserRequestRoleAdmin  UpdateUserRequestRole = "admin"
+	UpdateUserRequestRoleEditor UpdateUserRequestRole = "editor"
+	UpdateUserRequestRoleViewer UpdateUserRequestRole = "viewer"
+)
+
+// Defines values for UserRole.
+const (
+	UserRoleAdmin  UserRole = "admin"
+	UserRoleEditor UserRole = "editor"
+	UserRoleViewer UserRole = "viewer"
+)
+
 // CreateUserRequest defines model for CreateUserRequest.
 type CreateUserRequest struct {
+	// Email Email address of the user, unique within the tenant
 	Email openapi_types.Email `json:"email"`
-	Name  string              `json:"name"`
+
+	// Name Display name of the user
+	Name string `json:"name"`
+
+	// Password Password for /auth/login. It must mix letters with digits or symbols and must not contain the local part of the email address.
+	Password *string `json:"password,omitempty"`
+
+	// Role Access level of a user. Editors may also change products; admins may also manage users and API keys.
+	Role *CreateUserRequestRole `json:"role,omitempty"`
 }
 
+// CreateUserRequestRole Access level of a user. Editors may also change products; admins may also manage users and API keys.
+type CreateUserRequestRole string
+
 // Error defines model for Error.
 type Error struct {
-	Code    string `json:"code"`
+	// Code Machine-readable error code, such as not_found
+	Code string `json:"code"`
+
+	// Details Per-field problems, e.g. for request validation failures
+	Details *[]ErrorDetail `json:"details,omitempty"`
+
+	// Field Request field the error refers to, when it concerns a single field
+	Field *string `json:"field,omitempty"`
+
+	// Message Human-readable description of the error
 	Message string `json:"message"`
 }
 
+// ErrorDetail defines model for .
+type ErrorDetail struct {
+	// Field Location of the problem, e.g. "name" for a body property or "limit" for a query parameter
+	Field string `json:"field"`
+
+	// Message Human-readable description of the problem
+	Message string `json:"message"`
+}
+
+// Problem defines model for Problem.
+type Problem struct {
+	// Code Machine-readable error code, such as not_found
+	Code string `json:"code"`
+
+	// Detail Explanation specific to this occurrence of the problem
+	Detail *string `json:"detail,omitempty"`
+
+	// Details Per-field problems, e.g. for request validation failures
+	Details *[]ErrorDetail `json:"details,omitempty"`
+
+	// Field Request field the error refers to, when it concerns a single field
+	Field *string `json:"field,omitempty"`
+
+	// Instance URI reference of the request that caused the problem
+	Instance *string `json:"instance,omitempty"`
+
+	// Message Human-readable description of the error
+	Message string `json:"message"`
+
+	// Status HTTP status code
+	Status int `json:"status"`
+
+	// Title Short summary of the problem type
+	Title string `json:"title"`
+
+	// Type URI reference identifying the problem type
+	Type string `json:"type"`
+}
+
+// Role Access level of a user. Editors may also change products; admins may also manage users and API keys.
+type Role string
+
 // UpdateUserRequest defines model for UpdateUserRequest.
 type UpdateUserRequest struct {
+	// Email New email address of the user, unique within the tenant
 	Email *openapi_types.Email `json:"email,omitempty"`
-	Name  *string              `json:"name,omitempty"`
+
+	// Name New display name of the user
+	Name *string `json:"name,omitempty"`
+
+	// Password Password for /auth/login. It must mix letters with digits or symbols and must not contain the local part of the email address.
+	Password *string `json:"password,omitempty"`
+
+	// Role Access level of a user. Editors may also change products; admins may also manage users and API keys.
+	Role *UpdateUserRequestRole `json:"role,omitempty"`
 }
 
+// UpdateUserRequestRole Access level of a user. Editors may also change products; admins may also manage users and API keys.
+type UpdateUserRequestRole string
+
 // User defines model for User.
 type User struct {
-	CreatedAt time.Time           `json:"createdAt"`
-	Email     openapi_types.Email `json:"email"`
-	Id        openapi_types.UUID  `json:"id"`
-	Name      string              `json:"name"`
-	UpdatedAt *time.Time          `json:"updatedAt,omitempty"`
+	// CreatedAt When the user was created
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Email Email address of the user, unique within the tenant
+	Email openapi_types.Email `json:"email"`
+
+	// Id Unique identifier of the user
+	Id openapi_types.UUID `json:"id"`
+
+	// Name Display name of the user
+	Name string `json:"name"`
+
+	// Role Access level of a user. Editors may also change products; admins may also manage users and API keys.
+	Role UserRole `json:"role"`
+
+	// UpdatedAt When the user was last changed
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
 }
 
+// UserRole Access level of a user. Editors may also change products; admins may also manage users and API keys.
+type UserRole string
+
 // ListUsersParams defines parameters for ListUsers.
 type ListUsersParams struct {
 	// Limit Maximum number of users to return
 	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor from the X-Next-Cursor header of the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
 }
 
 // CreateUserJSONRequestBody defines body for CreateUser for application/json ContentType.
@@ -94,6 +220,10 @@ func (siw *ServerInterfaceWrapper) ListUsers(c *gin.Context) {
 
 	var err error
 
+	c.Set(BearerAuthScopes, []string{"users:read"})
+
+	c.Set(ApiKeyAuthScopes, []string{"users:read"})
+
 	// Parameter object where we will unmarshal all parameters from the context
 	var params ListUsersParams
 
@@ -105,6 +235,14 @@ func (siw *ServerInterfaceWrapper) ListUsers(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -118,6 +256,10 @@ func (siw *ServerInterfaceWrapper) ListUsers(c *gin.Context) {
 // CreateUser operation middleware
 func (siw *ServerInterfaceWrapper) CreateUser(c *gin.Context) {
 
+	c.Set(BearerAuthScopes, []string{"users:write"})
+
+	c.Set(ApiKeyAuthScopes, []string{"users:write"})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -142,6 +284,10 @@ func (siw *ServerInterfaceWrapper) DeleteUser(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{"users:write"})
+
+	c.Set(ApiKeyAuthScopes, []string{"users:write"})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -166,6 +312,10 @@ func (siw *ServerInterfaceWrapper) GetUserById(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{"users:read"})
+
+	c.Set(ApiKeyAuthScopes, []string{"users:read"})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -190,6 +340,10 @@ func (siw *ServerInterfaceWrapper) UpdateUser(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{"users:write"})
+
+	c.Set(ApiKeyAuthScopes, []string{"users:write"})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -237,20 +391,41 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xXTW/jNhD9K8S0RzZSPgosdNttisLAFigK7GmRAyONbS74FXLoRgj03wuSihVbStoA",
-	"aWCge7IkDzlv5r15lB6gtdpZg4YCNA8Q2i1qkS9/8SgIvwT0f+JdxEDpofPWoSeJOQS1kCpdrK3XgqAZ",
-	"n3Cg3iE0EMhLs4GBgxEaU6QW95/RbGgLzXldc9DS7O9nywYOHu+i9NhB83W/ed7rZh9tb79hSynJr95b",
-	"P4fZ2i7nnoHSGILYLP13lDnvMMUv5f7iuhNo1xxWwKWOZG67j3SAJhXwE0mNS4heAV52B3Exyu6lGmd/",
-	"xNzKV6A7YiunOxALf1LxnLy0Xpq1Tek6DK2XjqQ10OTuMS2M2KBGQwxN56xMs8KBJCkcYwL7+McKOOzQ",
-	"h7Ly/Kw+q1Mx1qERTkIDl2f12SVwcIK2mYUqpqXpaoO51kSRSKlXHTTwWQbKm+c1XmikHP71GOXv4l7q",
-	"qJmJ+hY9s2uWN2ZkmUeK3kCqDxq4i+j7x540oKSWBHwc+lL9WkRF0FzUfOq8NHR5keRf8kxSHO/2DZWG",
-	"cIMehuEmMRKcNaEI7qKuyyQaQpNrFc4p2eZqq28h1fHwBIgk1Hnhjx7X0MAP1eRT1WhSVdb2JHrhvegL",
-	"m4f9SY3cdyUt+PmVaF4CUTxnIevKEHojFAvod+gZjoEcQtRa+P4RmVBqhMaBxCYxDOX+ZuDgbFjQxmTO",
-	"ULSPgT7Zrn+zsubuPxyOGfmIw4zl8zcDUMidtzWP5DjNLMS2xRDWUak+EXv1PsTuhJIdk8ZFOik5FdKY",
-	"YAb/ypJaUNTAR9+pHtLPqhvK4CsknOvsOj8fdfaiCWVeVtePVpNMbnKakgmO9fPUev7huFhwlKtn/LrU",
-	"siSOq/+epozAWGJrG013UuooXDLxnDL48jH0G+ZT6FOfCTwxDdTv4zcdkpAqfFdRlsMoIXbbF7IXDq24",
-	"IKTpDfkkdPT2Z+b8E+BfnZnvpOHxrfpEzsz/+xgVrTxvxjk6L18akGvcobIuf5OUKOAQvYIGtkSuqSpl",
-	"W6G2NlDzof5QV8LJancOw83wdwAAAP//JYmjg/IPAAA=",
+	"H4sIAAAAAAAC/+xab2/cNvL+KgP+fu9O+ydODvVtcC/SOGm3TZsgTZACsRHQ0uyKNUUq5MheIdjvfhiS",
+	"+1eK4wt8QbfdV15LFIczz8w8Dyl9ErmtamvQkBeTT8LnJVYy/HzqUBK+9ehe48cGPfHF2tkaHSkMQ7CS",
+	"SvOPAn3uVE3KGjERz/gyyKJw6D3YGVCJ0Hh0GTRGfWwQbhSVyoTrhEYaEpmYWVdJEpM0ayaorVFMhCen",
+	"zFwsM2FkhV1rZ8rXWrbAd7eNiUxUcvECzZxKMXkwHmeiUmb9f8/8tfT+xrqia+NVugMz62AkGypH2s6V",
+	"GcKUoGo8QaUWoJEInQ/eQaHmijxYB76tLq32IE0RxxpLkFtDMoVA21xqqKWj1fpxO4LDPU9OTnc9Odl3",
+	"JRM3ThG+NLoVE3INLjPhrA6x+3+HMzER/zfa4D5KoI9e85glD8aPjXJYiMn7NRoh+BdrU/byD8yJo/bM",
+	"Oeu6uZHbogesX2ReKoMDh7KQlxoB+WngwRn4Ji9Beo7Ph5ltTNGXBAWSVNr3YIRuMFOoC6idvdRY+Qxw",
+	"OB8GzFxMYbiWWhWSn4CZVLpx6EUmFGHluy6E2bqGXtg8zpDASuaStfMQqXMRzEq4tEULadqWs+FcaFUp",
+	"Wg/42KBrGXxZIaHrc7lC7+W8J5o/NpU0m1hu3dxbW3fWPZSjqxtTHaAzsRjM7YAvDmIdRuDPAhy8yjRe",
+	"Oidb/v8z0UvNBCJUIdlDCjicce2QzeCmRAMq1EiOzniQ4JWZa4TVMu8zRMH8FwMU0vmW+Cwz8SrFevJJ",
+	"SK1fzsTk/e31Jmv1If3+EKtome3nYEz3nh67qLU0MQ99jbmaqRzIApXKg83zxjk0OX4xDzKhjCdp8p7Y",
+	"vX09jbBsz7SqJColQS4bj8WXLHiS1PRU7I9v3ryCeBNSfNOzyhDOkeMhSJHuWdtvpXUEvqkq6do9LyHM",
+	"0rOQeOF2N1WBhtSsVWZ+hzn3smQ1KKx57Xg3WS6y/ap4/hS+Ox1/tzaX2twQXiM1zmABjBPKgn0NyRLL",
+	"hJeYa4WGQPorH5tKXWsVe9QozfePP7w1j8Po+PAmy0A6hCuseQLABaHxnFQVVpfo/JDj9tr2QfAkz9F7",
+	"0HiNmlclA+0O4VmhyDoPlWxBam8hL6WZh0gWTU7+MciiUmZrQCWNnEfWjjT55NUUrrAN1IemqTi21wpv",
+	"Qn/EML/IRJhGXHRAycTbuvhK7fIr3uyy7/9cv7DF4qhh7knDbPfUtZ7ptGrOjB7JEhRv8YS6wXu3qjVG",
+	"BW6khzR4G3jOuQGpqrf3fGOtrHoy4G2cMHU4hW4v1dazNo0q7kWAd2b4Oggz0dTF3aHR0lNqOnfFZ6+P",
+	"B/d3lG9aeraVJH0aoEvpR2F8FMZ/c2HcKejDkRKsXjFvnKL2N/Yg5r+s1c/YPmmo7HEl2gtJXMWiTgLN",
+	"ZyvSACqdbeYlcK8bhNXBmxLDc3MnDfkAgM9tjZ4B3yKcwAfsjGJrJcoieJJS7/fBk1fTwc/YbnCLi2Uc",
+	"LlE6dKtlx/+er5rjT+/eiH1V+tO7NyAjNGSv0AzhZY0u1LgHrTxtL5NKbCFlRlSa4RnIpXMquOHXHsWG",
+	"7WuZ48Aj1zh7di7C/XMBuZaqYidD2rAXcbUbr0qiWiyXYf8ysz1c59GlZKjQEKApaqsM+bU8j2M8J4jI",
+	"xDU6H598MBwPxxwuW6ORtRIT8XA4Hj4ULK6oDAkwCvnFv+YYKMmu4jItuBEqT2Hy8ExqYD7sB/e7/kJV",
+	"TQWmYbHNmR8Tlyy4oPtXOIdmuIE5dMpVeGT0fiYbTWJyMt5iPGXo4UmUXmxnIyHTf90N1zLbX+PLWrJq",
+	"yBvnrYOZs1UA7/fBr7igwdN4OebhprnitbKNh5pbQr8Lcb4dH/Zr7yITDn1tjY9ldzIeRwI1hIZiIW52",
+	"OrzD2Rxh8q81e92mNhinbpdeLvdrgTFdA5SBdQU6LOCyjYXJfTNJixiLYPiFMlc9nf75Uzg9OT0FrcxV",
+	"3LQjGFxQiFeU3g71v88FXzwXj0Feek5im7S29HHordHLxA5E3WUk6LhR7Szg68yxwUf/JUC34ZIORJbZ",
+	"zhzb29m7z7U6memBdWqC8knpLYITDw7NiV+UZx3AKkqt/HEYZL7UPjr18NCcerrxALTMr9anT6w8IpOw",
+	"wy7tEP55iLlH6IzU4NFdo0uCK/jy6NB8WanXAmWhlUHARY5YYLGjoQIFbsuQ9yL00wnrUHHB5LOtrfbu",
+	"XmQiHfit+rHUOjZkkQmSc79+hKXcIuz1bENM6uNQBrX1PXS9edMlorZFT9/bor03DLqv0pa7Mnp1wLHL",
+	"dvfXhyLJdVHj62th6Zsg9WaN1q047G6uTN3QsZkfbDN/NP7XoXm4e5CnPEjNbavlzU7jjxR18BQVzqM/",
+	"z1Gr2zskFRs/SDB4sz4T3eUpth23k6NP/GdaLKNU10jY5aqzcD1x1a17Sx4D07PV9ov3rpvdV7Qk9jlo",
+	"W+B/4TS4Z3f26DPb8OjLX4pgUvyODHO4DHNw/SvUkrEE8RXAkVD+hoQS2386H+8hk6z/QPIHDOeR37fT",
+	"4s9HG+Nvs81ZvR87Es+ReI7EcySe42Hb3Q/bfkBKnMMvPKZnvcxTNz3Ms/kS609BPPd/ttf91OxOZ3vf",
+	"iPTSdzPHs70jAR4J8B4J8HhAeaT1v8J+MrLX5/eT0TBHto+yz/h7LVuHD2ziKJGJxun0fc5kNAqfBZfW",
+	"0+R0fDrmz51G1w/E8mL5nwEAKErHEXk2AAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -302,6 +477,18 @@ func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
 		}
 		res[rawPath] = rawFunc
 	}
+	for rawPath, rawFunc := range externalRef0.PathToRawSpec(path.Join(path.Dir(pathToFile), "../../schemas/Problem.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
+	for rawPath, rawFunc := range externalRef0.PathToRawSpec(path.Join(path.Dir(pathToFile), "../../schemas/Role.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
 	for rawPath, rawFunc := range externalRef0.PathToRawSpec(path.Join(path.Dir(pathToFile), "../../schemas/UpdateUserRequest.yaml")) {
 		if _, ok := res[rawPath]; ok {
 			// it is not possible to compare functions in golang, so always overwrite the old value